@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/notify"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"log"
+	"strings"
+)
+
+//newGeoIP builds the geoip service from the database path settings
+func newGeoIP() *geoip.Service {
+	return geoip.NewService(geoip.Paths{
+		City:        viper.GetString("GEOLITE_PATH"),
+		AnonymousIP: viper.GetString("ANONYMOUS_IP_PATH"),
+		ASN:         viper.GetString("ASN_PATH"),
+	})
+}
+
+//newDetector builds the detector with the rules and tables from the configuration
+func newDetector(storer store.Storer, service geoip.GeoIP) (*detector.Detector, error) {
+	d := detector.NewDetector(storer, service, detector.NewImpossibleTravel(feasibility(), viper.GetFloat64("MAX_RADIUS_KM")))
+
+	trusted, err := trustedNetworks()
+	if err != nil {
+		return nil, err
+	}
+	d.Trust(trusted)
+
+	weights, err := riskWeights()
+	if err != nil {
+		return nil, err
+	}
+	err = requireHistory(storer, weights)
+	if err != nil {
+		return nil, err
+	}
+	d.SetScorer(detector.NewScorer(weights, detector.Hours{
+		Start: viper.GetInt("UNUSUAL_HOURS_START"),
+		End:   viper.GetInt("UNUSUAL_HOURS_END"),
+	}))
+
+	notifier, err := notifiers()
+	if err != nil {
+		return nil, err
+	}
+	d.Notify(notifier)
+	return d, nil
+}
+
+//historySignals are the risk signals that need the user's history from the store
+var historySignals = []string{detector.SignalNewCountry, detector.SignalNewASN}
+
+//requireHistory fails when RISK_WEIGHTS gives weight to a signal that needs the user's history and the store doesn't
+//keep it. With their default weights they are left to never fire.
+func requireHistory(storer store.Storer, weights detector.Weights) error {
+	if _, ok := storer.(store.HistoryStorer); ok {
+		return nil
+	}
+	for _, name := range historySignals {
+		if weights[name] > 0 {
+			return errors.Errorf("RISK_WEIGHTS gives %s a weight, but the %s store doesn't keep the user's history",
+				name, viper.GetString("STORE_BACKEND"))
+		}
+	}
+	log.Printf("the %s store doesn't keep the user's history, the %s signals never fire\n",
+		viper.GetString("STORE_BACKEND"), strings.Join(historySignals, " and "))
+	return nil
+}
+
+//notifiers builds the alert outputs listed in ALERT_OUTPUTS, which is a list in the config file or comma separated
+//in the environment
+func notifiers() (notify.Notifiers, error) {
+	var outputs notify.Notifiers
+	for _, output := range strings.Split(strings.Join(viper.GetStringSlice("ALERT_OUTPUTS"), ","), ",") {
+		switch strings.TrimSpace(output) {
+		case "":
+		case "log":
+			outputs = append(outputs, notify.Log{})
+		case "webhook":
+			url := viper.GetString("ALERT_WEBHOOK_URL")
+			if len(url) == 0 {
+				return nil, errors.New("ALERT_WEBHOOK_URL is required for the webhook alert output")
+			}
+			outputs = append(outputs, notify.NewWebhook(url, viper.GetDuration("ALERT_WEBHOOK_TIMEOUT")))
+		default:
+			return nil, errors.Errorf("unknown alert output %q", output)
+		}
+	}
+	return outputs, nil
+}
+
+//feasibility reads the travel model settings. MAX_SPEED is in mph and predates them, it is only taken as the airliner
+//speed when AIR_SPEED_KMH isn't set. AIR_SPEED_KMH has no viper default so that it can be told apart from unset.
+func feasibility() detector.Feasibility {
+	f := detector.Feasibility{
+		GroundSpeedKmh:  viper.GetFloat64("GROUND_SPEED_KMH"),
+		ShortHopKm:      viper.GetFloat64("SHORT_HOP_KM"),
+		AirSpeedKmh:     detector.DefaultFeasibility.AirSpeedKmh,
+		AirportOverhead: viper.GetDuration("AIRPORT_OVERHEAD"),
+	}
+	switch {
+	case viper.IsSet("AIR_SPEED_KMH"):
+		f.AirSpeedKmh = viper.GetFloat64("AIR_SPEED_KMH")
+		if viper.IsSet("MAX_SPEED") {
+			log.Println("MAX_SPEED is deprecated and ignored, AIR_SPEED_KMH is set")
+		}
+	case viper.IsSet("MAX_SPEED"):
+		log.Println("MAX_SPEED is deprecated, set AIR_SPEED_KMH instead")
+		f.AirSpeedKmh = viper.GetFloat64("MAX_SPEED") * detector.KmPerMile
+	}
+	return f
+}
+
+//trustedNetworks reads TRUSTED_NETWORKS, which is a list in the config file, or a JSON array when it comes from
+//the environment
+func trustedNetworks() (*geoip.TrustedNetworks, error) {
+	var networks []geoip.TrustedNetwork
+	if raw, ok := viper.Get("TRUSTED_NETWORKS").(string); ok {
+		if len(raw) > 0 {
+			err := json.Unmarshal([]byte(raw), &networks)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse TRUSTED_NETWORKS")
+			}
+		}
+	} else {
+		err := viper.UnmarshalKey("TRUSTED_NETWORKS", &networks)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse TRUSTED_NETWORKS")
+		}
+	}
+	return geoip.NewTrustedNetworks(networks)
+}
+
+//riskWeights reads RISK_WEIGHTS, a map of signal name to weight in the config file, or a JSON object when it comes
+//from the environment. Signals it leaves out keep their default weight.
+func riskWeights() (detector.Weights, error) {
+	weights := detector.Weights{}
+	if raw, ok := viper.Get("RISK_WEIGHTS").(string); ok {
+		if len(raw) > 0 {
+			err := json.Unmarshal([]byte(raw), &weights)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse RISK_WEIGHTS")
+			}
+		}
+	} else {
+		err := viper.UnmarshalKey("RISK_WEIGHTS", &weights)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse RISK_WEIGHTS")
+		}
+	}
+	for name := range weights {
+		if _, ok := detector.DefaultWeights[name]; !ok {
+			return nil, errors.Errorf("unknown signal %q in RISK_WEIGHTS", name)
+		}
+	}
+	return weights, nil
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"database/sql"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRequireHistory(t *testing.T) {
+	dynamo := store.NewDynamoStore(nil, "events")
+	require.NoError(t, requireHistory(dynamo, detector.Weights{}))
+	require.NoError(t, requireHistory(dynamo, detector.Weights{detector.SignalNewCountry: 0, detector.SignalNewASN: 0}))
+	require.Error(t, requireHistory(dynamo, detector.Weights{detector.SignalNewASN: 5}))
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, requireHistory(store.NewSqliteDb(db), detector.Weights{detector.SignalNewASN: 5}))
+}
+
+func TestFeasibility(t *testing.T) {
+	defer viper.Reset()
+
+	require.Equal(t, detector.DefaultFeasibility.AirSpeedKmh, feasibility().AirSpeedKmh)
+	viper.Set("MAX_SPEED", 500)
+	require.Equal(t, 500*detector.KmPerMile, feasibility().AirSpeedKmh)
+	// the setting in km/h wins over the legacy one
+	viper.Set("AIR_SPEED_KMH", 800)
+	require.Equal(t, float64(800), feasibility().AirSpeedKmh)
+}
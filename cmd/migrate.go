@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/spf13/cobra"
+	"log"
+	"time"
+)
+
+//migratable is implemented by the stores that have versioned sql migrations
+type migratable interface {
+	Migrator() (*store.Migrator, error)
+}
+
+var migrateSteps int
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the database schema of the sql store backends",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator, closer := newMigrator()
+		defer closer()
+
+		applied, err := migrator.Up(context.Background())
+		for _, m := range applied {
+			fmt.Printf("applied %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the most recently applied migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator, closer := newMigrator()
+		defer closer()
+
+		reverted, err := migrator.Down(context.Background(), migrateSteps)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range reverted {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations have been applied",
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator, closer := newMigrator()
+		defer closer()
+
+		status, err := migrator.Status(context.Background())
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, s := range status {
+			applied := "pending"
+			if s.Applied() {
+				applied = "applied " + time.Unix(s.AppliedAt, 0).UTC().Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, applied)
+		}
+	},
+}
+
+//newMigrator builds the configured store without opening it, so nothing is migrated behind our back
+func newMigrator() (*store.Migrator, func()) {
+	s, err := newStore()
+	if err != nil {
+		log.Fatal(err)
+	}
+	m, ok := s.(migratable)
+	if !ok {
+		log.Fatal("the configured STORE_BACKEND has no sql migrations")
+	}
+	migrator, err := m.Migrator()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return migrator, func() {
+		s.Close()
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd)
+
+	migrateDownCmd.Flags().IntVar(&migrateSteps, "steps", 1, "number of migrations to revert")
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/internal/httpd"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+)
+
+var replayMemory bool
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay [file]",
+	Short: "Run a JSONL file of events through the detector and write a verdict per line to stdout",
+	Long: `Replay reads events, one JSON event request per line, from the file or from stdin when there is no file or
+it is -. Every event is validated, enriched and detected the same way the web service does it, and a result is
+written to stdout for each, in order, with its verdict or why it failed. Blank lines are skipped.
+
+Events are stored in the configured store unless --memory is given, which uses a fresh in memory store that is
+thrown away afterwards. Alert outputs are never notified.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		in := os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		service := newGeoIP()
+		storer, err := newReplayStore()
+		if err != nil {
+			log.Fatal(err)
+		}
+		d, err := newDetector(storer, service)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// an investigation shouldn't page anyone
+		d.Notify(nil)
+
+		for _, m := range []interface {
+			Open() error
+		}{service, storer} {
+			err := m.Open()
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		defer service.Close()
+		defer storer.Close()
+
+		out := bufio.NewWriter(os.Stdout)
+		defer out.Flush()
+		processed, failed, err := replay(context.Background(), d, httpd.NewEvenRequestMiddleware(), in, out)
+		log.Printf("replayed %d events, %d failed\n", processed+failed, failed)
+		if err != nil {
+			out.Flush()
+			log.Fatal(err)
+		}
+	},
+}
+
+//newReplayStore is the configured store, or with --memory an in memory sqlite store
+func newReplayStore() (storeModule, error) {
+	if !replayMemory {
+		return newStore()
+	}
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	// every connection to :memory: gets a database of its own, so there can only be one
+	db.SetMaxOpenConns(1)
+	return store.NewSqliteDb(db), nil
+}
+
+//replay detects every event read from in, in order, and writes a result per event to out. One event failing doesn't
+//stop the replay, only failing to read or write does.
+func replay(ctx context.Context, d *detector.Detector, validator *httpd.EventRequestValidator, in io.Reader, out io.Writer) (int, int, error) {
+	reader := bufio.NewReader(in)
+	encoder := json.NewEncoder(out)
+	processed, failed := 0, 0
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return processed, failed, readErr
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			result := &model.BatchResult{Index: processed + failed}
+			verdict, err := detect(ctx, d, validator, line, result)
+			if err != nil {
+				result.Error = err.Error()
+				failed++
+			} else {
+				result.Verdict = verdict.Response()
+				processed++
+			}
+			if err := encoder.Encode(result); err != nil {
+				return processed, failed, err
+			}
+		}
+		if readErr == io.EOF {
+			return processed, failed, nil
+		}
+	}
+}
+
+func detect(ctx context.Context, d *detector.Detector, validator *httpd.EventRequestValidator, line []byte, result *model.BatchResult) (*detector.Verdict, error) {
+	request, err := validator.Validate(line)
+	if err != nil {
+		return nil, err
+	}
+	result.EventID = request.EventID
+	return d.Detect(ctx, request)
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	replayCmd.Flags().BoolVar(&replayMemory, "memory", false, "use a throwaway in memory store instead of the configured one")
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/internal/httpd"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"net"
+	"strings"
+	"testing"
+)
+
+//cityGeoIP puts addresses ending in an even number in new york and the rest in los angeles
+type cityGeoIP struct{}
+
+func (c cityGeoIP) AnonymousIP(ip net.IP) (*geoip.AnonymousIP, error) {
+	return nil, geoip.ErrAnonymousIPUnavailable
+}
+
+func (c cityGeoIP) IsAnonymous(ip *geoip.AnonymousIP) bool {
+	return false
+}
+
+func (c cityGeoIP) Location(ip net.IP) (*geoip.Location, error) {
+	if ip.To4()[3]%2 == 0 {
+		return &geoip.Location{Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5}, nil
+	}
+	return &geoip.Location{Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5}, nil
+}
+
+func (c cityGeoIP) ASN(ip net.IP) (*geoip.ASN, error) {
+	return nil, geoip.ErrASNUnavailable
+}
+
+func TestReplay(t *testing.T) {
+	replayMemory = true
+	defer func() { replayMemory = false }()
+	storer, err := newReplayStore()
+	require.NoError(t, err)
+	require.NoError(t, storer.Open())
+	defer storer.Close()
+	d := detector.NewDetector(storer, cityGeoIP{}, detector.NewImpossibleTravel(detector.DefaultFeasibility, 0))
+
+	// new york, a bad line, los angeles an hour later, and the first event again, without a trailing newline
+	in := strings.Join([]string{
+		`{"username": "foo", "unix_timestamp": 1561600005, "event_uuid": "00000000-0000-4000-8000-000000000000", "ip_address": "68.193.88.10"}`,
+		``,
+		`{"username": "foo", "unix_timestamp": "yesterday"}`,
+		`  {"username": "foo", "unix_timestamp": 1561603605, "event_uuid": "00000000-0000-4000-8000-000000000001", "ip_address": "68.193.88.11"}  `,
+		`{"username": "foo", "unix_timestamp": 1561600005, "event_uuid": "00000000-0000-4000-8000-000000000000", "ip_address": "68.193.88.10"}`,
+	}, "\n")
+	out := &bytes.Buffer{}
+	processed, failed, err := replay(context.Background(), d, httpd.NewEvenRequestMiddleware(), strings.NewReader(in), out)
+	require.NoError(t, err)
+	require.Equal(t, 3, processed)
+	require.Equal(t, 1, failed)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	var results []*model.BatchResult
+	for i, line := range lines {
+		result := &model.BatchResult{}
+		require.NoError(t, json.Unmarshal([]byte(line), result))
+		require.Equal(t, i, result.Index)
+		results = append(results, result)
+	}
+	require.False(t, results[0].Verdict.Suspicious)
+	require.NotEmpty(t, results[1].Error)
+	require.Nil(t, results[1].Verdict)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", results[2].EventID)
+	require.True(t, results[2].Verdict.Suspicious)
+	require.Equal(t, int64(1561600005), results[2].Verdict.PrecedingIPAccess.Timestamp)
+	// replaying an event that is already stored gives back its verdict
+	require.Equal(t, results[0].Verdict.Current, results[3].Verdict.Current)
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/spf13/cobra"
+	"log"
+	"os"
+)
+
+var (
+	rescoreUsers []string
+	rescoreWrite bool
+)
+
+// rescoreCmd represents the rescore command
+var rescoreCmd = &cobra.Command{
+	Use:   "rescore",
+	Short: "Evaluate stored events again with the current configuration and report the alerts that would change",
+	Long: `Rescore walks the stored events of every user, or of the users named with --user, and evaluates each again
+with the current rules and settings. It writes a report of the verdicts that changed and the alerts that would be
+added or removed to stdout. Nothing is changed unless --write is given, then the new verdicts are saved, missed
+alerts raised and the alerts of events that are no longer suspicious resolved. Alert outputs are never notified.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		service := newGeoIP()
+		storer, err := newStore()
+		if err != nil {
+			log.Fatal(err)
+		}
+		d, err := newDetector(storer, service)
+		if err != nil {
+			log.Fatal(err)
+		}
+		d.Notify(nil)
+
+		for _, m := range []interface {
+			Open() error
+		}{service, storer} {
+			err := m.Open()
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		defer service.Close()
+		defer storer.Close()
+
+		report, err := d.Rescore(context.Background(), rescoreUsers, rescoreWrite)
+		if err != nil {
+			log.Fatal(err)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("rescored %d events for %d users, %d changed, %d alerts added, %d removed\n",
+			report.Events, report.Users, report.Changed, len(report.Added), len(report.Removed))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rescoreCmd)
+
+	rescoreCmd.Flags().StringArrayVar(&rescoreUsers, "user", nil, "a user to rescore, can be repeated, every user when left out")
+	rescoreCmd.Flags().BoolVar(&rescoreWrite, "write", false, "save the new verdicts, raise the missed alerts and resolve the removed ones")
+}
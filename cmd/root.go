@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/edwardsb/secureworks/detector"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -63,11 +65,29 @@ func initConfig() {
 	viper.AutomaticEnv() // read in environment variables that match
 
 	viper.SetDefault("GEOLITE_PATH", "./GeoLite2-City.mmdb")
+	viper.SetDefault("ANONYMOUS_IP_PATH", "")
+	viper.SetDefault("ASN_PATH", "")
+	viper.SetDefault("STORE_BACKEND", "sqlite")
 	viper.SetDefault("DB_PATH", "./secureworksdb")
-	viper.SetDefault("MAX_SPEED", 500)
+	viper.SetDefault("POSTGRES_DSN", "")
+	viper.SetDefault("MIGRATE_ON_START", true)
+	viper.SetDefault("DYNAMO_ENDPOINT", "")
+	viper.SetDefault("DYNAMO_REGION", "us-east-1")
+	viper.SetDefault("DYNAMO_TABLE", "events")
+	viper.SetDefault("GROUND_SPEED_KMH", detector.DefaultFeasibility.GroundSpeedKmh)
+	viper.SetDefault("SHORT_HOP_KM", detector.DefaultFeasibility.ShortHopKm)
+	viper.SetDefault("AIRPORT_OVERHEAD", detector.DefaultFeasibility.AirportOverhead)
+	viper.SetDefault("MAX_RADIUS_KM", 0)
+	viper.SetDefault("TRUSTED_NETWORKS", "")
+	viper.SetDefault("RISK_WEIGHTS", "")
+	viper.SetDefault("UNUSUAL_HOURS_START", detector.DefaultUnusualHours.Start)
+	viper.SetDefault("UNUSUAL_HOURS_END", detector.DefaultUnusualHours.End)
+	viper.SetDefault("ALERT_OUTPUTS", "log")
+	viper.SetDefault("ALERT_WEBHOOK_URL", "")
+	viper.SetDefault("ALERT_WEBHOOK_TIMEOUT", 5*time.Second)
 
-	// If a config file is found, read it in.
+	// If a config file is found, read it in. Stdout is left to the commands, replay writes its results there.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
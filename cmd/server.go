@@ -1,12 +1,8 @@
 package cmd
 
 import (
-	"database/sql"
-	"github.com/edwardsb/secureworks/geoip"
 	"github.com/edwardsb/secureworks/internal/httpd"
-	"github.com/edwardsb/secureworks/store"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"log"
 	"os"
 	"os/signal"
@@ -25,17 +21,21 @@ var serverCmd = &cobra.Command{
 		}
 
 		// start creating dependencies
-		geoip := geoip.NewService(viper.GetString("GEOLITE_PATH"))
-		db, err := sql.Open("sqlite3", viper.GetString("DB_PATH"))
+		geoip := newGeoIP()
+		store, err := newStore()
 		if err != nil {
 			log.Panic(err)
 		}
 		// start injecting dependencies
-		store := store.NewSqliteDb(db)
-		httpServer := httpd.NewHTTPServer(store, geoip)
+		detector, err := newDetector(store, geoip)
+		if err != nil {
+			log.Panic(err)
+		}
+		httpServer := httpd.NewHTTPServer(detector, store)
 
 
-		modules := []Module{geoip, httpServer, store}
+		// the store runs its migrations when it opens, so it has to be ready before the server takes requests
+		modules := []Module{geoip, store, httpServer}
 		for _, m := range modules {
 			err := m.Open()
 			if err != nil {
@@ -51,8 +51,9 @@ var serverCmd = &cobra.Command{
 		go func() {
 			<- sigChan
 			log.Println("closing modules")
-			for _, m := range modules {
-				err := m.Close()
+			// in reverse, so the server stops taking requests before the store goes away
+			for i := len(modules) - 1; i >= 0; i-- {
+				err := modules[i].Close()
 				if err != nil {
 					log.Fatal(err)
 				}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/spf13/viper"
+	"log"
+)
+
+//storeModule is a Storer that also has to be opened and closed along with the other modules
+type storeModule interface {
+	store.Storer
+	Open() error
+	Close() error
+}
+
+//newStore builds the store selected by STORE_BACKEND
+func newStore() (storeModule, error) {
+	switch backend := viper.GetString("STORE_BACKEND"); backend {
+	case "sqlite":
+		db, err := sql.Open("sqlite3", viper.GetString("DB_PATH"))
+		if err != nil {
+			return nil, err
+		}
+		s := store.NewSqliteDb(db)
+		s.AutoMigrate = viper.GetBool("MIGRATE_ON_START")
+		return s, nil
+	case "postgres":
+		db, err := sql.Open("postgres", viper.GetString("POSTGRES_DSN"))
+		if err != nil {
+			return nil, err
+		}
+		s := store.NewPostgresDb(db)
+		s.AutoMigrate = viper.GetBool("MIGRATE_ON_START")
+		return s, nil
+	case "dynamo":
+		config := &aws.Config{Region: aws.String(viper.GetString("DYNAMO_REGION"))}
+		if endpoint := viper.GetString("DYNAMO_ENDPOINT"); endpoint != "" {
+			config.Endpoint = aws.String(endpoint)
+		}
+		sess, err := session.NewSession(config)
+		if err != nil {
+			return nil, err
+		}
+		log.Println("the dynamo store has no alerts, network lists or user history, their endpoints answer 501 Not Implemented")
+		log.Println("the dynamo store reads neighbouring events from an eventually consistent index, logins moments apart can miss each other")
+		return store.NewDynamoStore(dynamodb.New(sess), viper.GetString("DYNAMO_TABLE")), nil
+	default:
+		return nil, fmt.Errorf("unknown STORE_BACKEND %q", backend)
+	}
+}
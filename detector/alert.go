@@ -0,0 +1,63 @@
+package detector
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/pkg/errors"
+	"time"
+)
+
+//raise keeps the event's alert in line with its verdict. A suspicious verdict raises an alert, an event only ever gets
+//one, so when it is evaluated again the alert it already has is revised with the new score and reasons instead. An
+//event that was evaluated before and is no longer suspicious has its alert resolved. Alerts analysts have closed are
+//left alone.
+func (d *Detector) raise(ctx context.Context, verdict *Verdict) error {
+	now := time.Now()
+	alert := newAlert(verdict, now)
+	if verdict.Suspicious() {
+		_, err := d.alerts.PutAlert(ctx, alert)
+		if err == nil {
+			return nil
+		}
+		if err != store.ErrDuplicateAlert {
+			return errors.Wrap(err, "failed to store alert")
+		}
+		// the event already has an alert, which is revised below
+	} else {
+		// an event that is evaluated for the first time has no alert yet
+		if len(verdict.Current.Verdict) == 0 {
+			return nil
+		}
+		alert.Status = model.AlertResolved
+	}
+	_, err := d.alerts.ReviseAlert(ctx, alert, now.Unix())
+	if err != nil {
+		return errors.Wrap(err, "failed to revise alert")
+	}
+	return nil
+}
+
+//newAlert builds the alert for a verdict, with the verdict's score and reasons
+func newAlert(verdict *Verdict, now time.Time) *model.Alert {
+	response := verdict.Response()
+	alert := &model.Alert{
+		EventID:        verdict.Current.EventID,
+		UserName:       verdict.Current.UserName,
+		EventTimestamp: verdict.Current.Timestamp,
+		Status:         model.AlertOpen,
+		Reasons:        response.Reasons,
+		CreatedAt:      now.Unix(),
+	}
+	if verdict.Preceding != nil {
+		alert.PrecedingEventID = verdict.Preceding.Access.EventID
+	}
+	if verdict.Subsequent != nil {
+		alert.SubsequentEventID = verdict.Subsequent.Access.EventID
+	}
+	if verdict.Risk != nil {
+		alert.Score = verdict.Risk.Score
+	}
+	alert.Severity = model.Severity(alert.Score)
+	return alert
+}
@@ -0,0 +1,74 @@
+package detector
+
+import (
+	"context"
+	"database/sql"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDetector_ReviseAlert(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	storer := store.NewSqliteDb(db)
+	require.NoError(t, storer.Open())
+	defer storer.Close()
+
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},  // new york
+		"81.2.69.160":   {Latitude: 51.5074, Longitude: -0.1278, AccuracyRadius: 5},   // london
+		"12.34.56.78":   {Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5}, // los angeles
+	}}
+	d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	ctx := context.Background()
+	detect := func(eventID string, hours int64, address string) {
+		_, err := d.Detect(ctx, &model.EventRequestValidated{
+			UnixTimestamp: 1561600005 + hours*3600,
+			Username:      "foo",
+			EventID:       eventID,
+			IPAddress:     address,
+		})
+		require.NoError(t, err)
+	}
+	alertFor := func(eventID string) *model.Alert {
+		alerts, err := storer.Alerts(ctx, model.AlertFilter{})
+		require.NoError(t, err)
+		for _, a := range alerts {
+			if a.EventID == eventID {
+				alert, err := storer.Alert(ctx, a.ID)
+				require.NoError(t, err)
+				return alert
+			}
+		}
+		return nil
+	}
+
+	// new york to los angeles in four hours is impossible
+	detect("e-100", 0, "68.193.88.103")
+	detect("e-400", 4, "12.34.56.78")
+	alert := alertFor("e-400")
+	require.Equal(t, "e-100", alert.PrecedingEventID)
+	require.Equal(t, model.AlertOpen, alert.Status)
+
+	// london arrives late, los angeles is still impossible to get to, from london now
+	detect("e-200", 2, "81.2.69.160")
+	alert = alertFor("e-400")
+	require.Equal(t, "e-200", alert.PrecedingEventID)
+	require.Equal(t, model.AlertOpen, alert.Status)
+	require.Len(t, alert.History, 1)
+	require.Equal(t, model.AlertActorDetector, alert.History[0].Actor)
+
+	// a login from los angeles an hour before makes it no longer suspicious, its alert is resolved
+	detect("e-300", 3, "12.34.56.78")
+	alert = alertFor("e-400")
+	require.Equal(t, model.AlertResolved, alert.Status)
+	require.Equal(t, "e-200", alert.PrecedingEventID)
+	require.Len(t, alert.History, 2)
+	require.Equal(t, model.AlertOpen, alert.History[1].PreviousStatus)
+	require.Equal(t, model.AlertResolved, alert.History[1].Status)
+	require.Equal(t, model.AlertOpen, alertFor("e-300").Status)
+}
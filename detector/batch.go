@@ -0,0 +1,58 @@
+package detector
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+	"sort"
+	"sync"
+)
+
+//batchWorkers is how many users a batch detects for at once
+const batchWorkers = 8
+
+//DetectBatch detects every request and returns a verdict or an error for each, in the order of the requests. One
+//request failing doesn't stop the others.
+//
+//A user's requests are detected one at a time in (timestamp, event id) order, so each one finds the events before it
+//already stored, rather than every late arrival re-evaluating its neighbour. Different users are detected concurrently.
+func (d *Detector) DetectBatch(ctx context.Context, requests []*model.EventRequestValidated) ([]*Verdict, []error) {
+	verdicts := make([]*Verdict, len(requests))
+	errs := make([]error, len(requests))
+
+	users := map[string][]int{}
+	for i, request := range requests {
+		users[request.Username] = append(users[request.Username], i)
+	}
+	work := make(chan []int, len(users))
+	for _, indexes := range users {
+		sort.SliceStable(indexes, func(a, b int) bool {
+			ra, rb := requests[indexes[a]], requests[indexes[b]]
+			if ra.UnixTimestamp != rb.UnixTimestamp {
+				return ra.UnixTimestamp < rb.UnixTimestamp
+			}
+			return ra.EventID < rb.EventID
+		})
+		work <- indexes
+	}
+	close(work)
+
+	var wg sync.WaitGroup
+	for w := 0; w < batchWorkers && w < len(users); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// every worker writes to the indexes of its own users only
+			for indexes := range work {
+				for _, i := range indexes {
+					if err := ctx.Err(); err != nil {
+						errs[i] = err
+						continue
+					}
+					verdicts[i], errs[i] = d.Detect(ctx, requests[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return verdicts, errs
+}
@@ -0,0 +1,79 @@
+package detector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDetector_DetectBatch(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	storer := store.NewSqliteDb(db)
+	require.NoError(t, storer.Open())
+	defer storer.Close()
+
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.102": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+		"68.193.88.103": {Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5},
+	}}
+	notifier := &recordingNotifier{}
+	d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	d.Notify(notifier)
+
+	// each user alternates between new york and los angeles an hour apart, sent newest first
+	var requests []*model.EventRequestValidated
+	for i := 9; i >= 0; i-- {
+		for u, user := range []string{"foo", "bar", "baz"} {
+			requests = append(requests, &model.EventRequestValidated{
+				UnixTimestamp: 1561600005 + int64(i)*3600,
+				Username:      user,
+				EventID:       fmt.Sprintf("00000000-0000-4000-8000-%06d%06d", u, i),
+				IPAddress:     fmt.Sprintf("68.193.88.%d", 102+i%2),
+			})
+		}
+	}
+	requests = append(requests, &model.EventRequestValidated{UnixTimestamp: 1561600005, Username: "foo",
+		EventID: "00000000-0000-4000-8000-999999999999", IPAddress: "not an address"})
+
+	verdicts, errs := d.DetectBatch(context.Background(), requests)
+	require.Len(t, verdicts, len(requests))
+	require.Len(t, errs, len(requests))
+	require.Error(t, errs[len(requests)-1])
+	require.Nil(t, verdicts[len(requests)-1])
+
+	for i, request := range requests[:len(requests)-1] {
+		require.NoError(t, errs[i])
+		require.Equal(t, request.EventID, verdicts[i].Current.EventID)
+		// in time order nothing comes after an event when it is detected
+		require.Nil(t, verdicts[i].Subsequent)
+		if request.UnixTimestamp == 1561600005 {
+			require.Nil(t, verdicts[i].Preceding)
+			continue
+		}
+		require.Equal(t, request.UnixTimestamp-3600, verdicts[i].Preceding.Access.Timestamp, request.EventID)
+		require.Equal(t, request.Username, verdicts[i].Preceding.Access.UserName)
+		require.True(t, verdicts[i].Suspicious())
+
+		record, err := storer.Event(context.Background(), request.EventID)
+		require.NoError(t, err)
+		stored := &Verdict{}
+		require.NoError(t, json.Unmarshal(record.Verdict, stored))
+		require.Equal(t, verdicts[i].Preceding.Access.EventID, stored.Preceding.Access.EventID)
+	}
+	require.Empty(t, notifier.notifications)
+
+	// sending the batch again returns the same verdicts
+	again, errs := d.DetectBatch(context.Background(), requests[:3])
+	for i := range again {
+		require.NoError(t, errs[i])
+		require.Equal(t, verdicts[i].Preceding.Access.EventID, again[i].Preceding.Access.EventID)
+	}
+}
@@ -0,0 +1,465 @@
+package detector
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/notify"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/pkg/errors"
+	"log"
+	"net"
+	"strings"
+)
+
+//ErrEventConflict is returned when an event id that is already stored is sent again with a different user, timestamp
+//or ip address
+var ErrEventConflict = errors.New("the event id is already stored for a different event")
+
+//Detector is the engine that turns a validated login event into a verdict. It enriches the event with
+//geoip data, stores it, looks up the neighbouring accesses and runs every registered Rule against them.
+//The http handler, cli commands and anything embedding this module should all go through a Detector.
+type Detector struct {
+	store    store.Storer
+	networks store.NetworkStorer
+	history  store.HistoryStorer
+	alerts   store.AlertStorer
+	locker   store.UserLocker
+	locks    *userLocks
+	service  geoip.GeoIP
+	trusted  *geoip.TrustedNetworks
+	rules    []Rule
+	scorer   *Scorer
+	notifier notify.Notifier
+}
+
+//NewDetector is a constructor that takes the dependencies needed for enrichment and lookups, plus the rules to run.
+//If the store keeps network allow and deny lists they are consulted for every event, and if it can look up the
+//user's history that is used for the risk score. Events are scored with the default weights until SetScorer is called.
+//If the store keeps alerts one is raised for every suspicious event, and if it can lock users across processes that
+//is used on top of the detector's own per user locking.
+func NewDetector(storer store.Storer, service geoip.GeoIP, rules ...Rule) *Detector {
+	networks, _ := storer.(store.NetworkStorer)
+	history, _ := storer.(store.HistoryStorer)
+	alerts, _ := storer.(store.AlertStorer)
+	locker, _ := storer.(store.UserLocker)
+	return &Detector{
+		store:    storer,
+		networks: networks,
+		history:  history,
+		alerts:   alerts,
+		locker:   locker,
+		locks:    newUserLocks(),
+		service:  service,
+		rules:    rules,
+		scorer:   NewScorer(nil, DefaultUnusualHours),
+	}
+}
+
+//Register adds more rules to the detector, they are evaluated in the order they were registered
+func (d *Detector) Register(rules ...Rule) {
+	d.rules = append(d.rules, rules...)
+}
+
+//Trust sets the trusted networks, which are consulted before geoip when locating an event
+func (d *Detector) Trust(trusted *geoip.TrustedNetworks) {
+	d.trusted = trusted
+}
+
+//SetScorer replaces the scorer used for the risk score
+func (d *Detector) SetScorer(scorer *Scorer) {
+	d.scorer = scorer
+}
+
+//Notify sets the alert outputs told about changed verdicts, without one they aren't sent anywhere
+func (d *Detector) Notify(notifier notify.Notifier) {
+	d.notifier = notifier
+}
+
+//Detect enriches and stores the event, then evaluates it against the preceding and subsequent access for the user.
+//Events are idempotent on their event id, posting the same event again returns the verdict computed the first time.
+//Posting a different event under a stored event id returns ErrEventConflict.
+//
+//An event that arrives out of order becomes the new preceding access of its subsequent neighbour, so that
+//neighbour is evaluated again as well. It also becomes the new subsequent access of its preceding neighbour, which is
+//evaluated again when its stored verdict was evaluated against the access after it, the one that arrived earlier.
+//
+//Detection is serialized per user, from the insert through the neighbour lookups to the re-evaluation, so two
+//events for the same user arriving together always see each other. The alert outputs are notified once the user
+//is unlocked, so a slow output doesn't hold up the user's next events.
+func (d *Detector) Detect(ctx context.Context, request *model.EventRequestValidated) (*Verdict, error) {
+	verdict, notifications, err := d.detect(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	for _, notification := range notifications {
+		err = d.notifier.Notify(ctx, notification)
+		if err != nil {
+			log.Printf("failed to notify the verdict change of event %s: %v\n", notification.EventID, err)
+		}
+	}
+	return verdict, nil
+}
+
+//detect stores and evaluates the event with the user locked. It returns the notifications for the changes to the
+//neighbours' verdicts.
+func (d *Detector) detect(ctx context.Context, request *model.EventRequestValidated) (*Verdict, []*notify.Notification, error) {
+	unlock, err := d.lockUser(ctx, request.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	existing, err := d.store.Event(ctx, request.EventID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve event")
+	}
+	if existing != nil {
+		verdict, err := d.replayRequest(ctx, existing, request)
+		return verdict, nil, err
+	}
+
+	record, err := d.Enrich(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = d.store.Put(ctx, record)
+	if err == store.ErrDuplicateEvent {
+		// someone else stored the same event between our lookup and the insert
+		existing, err = d.store.Event(ctx, request.EventID)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to retrieve event")
+		}
+		verdict, err := d.replayRequest(ctx, existing, request)
+		return verdict, nil, err
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to store event")
+	}
+
+	verdict, err := d.evaluateStored(ctx, record)
+	if err != nil {
+		return nil, nil, err
+	}
+	var neighbours []*model.Record
+	if verdict.Subsequent != nil {
+		neighbours = append(neighbours, verdict.Subsequent.Access)
+	}
+	if verdict.Preceding != nil && hadSubsequent(verdict.Preceding.Access) {
+		neighbours = append(neighbours, verdict.Preceding.Access)
+	}
+	var notifications []*notify.Notification
+	for _, neighbour := range neighbours {
+		// the event and its verdict are already stored, failing the request now would only have the client
+		// retry and get the stored verdict back, so the neighbour's problems are logged instead
+		notification, err := d.reevaluate(ctx, neighbour, record.EventID)
+		if err != nil {
+			log.Printf("failed to re-evaluate event %s after %s: %v\n", neighbour.EventID, record.EventID, err)
+			continue
+		}
+		if notification != nil {
+			notifications = append(notifications, notification)
+		}
+	}
+	return verdict, notifications, nil
+}
+
+//hadSubsequent is true when the record's stored verdict was evaluated against an access after it
+func hadSubsequent(record *model.Record) bool {
+	stored := &Verdict{}
+	return len(record.Verdict) > 0 && json.Unmarshal(record.Verdict, stored) == nil && stored.Subsequent != nil
+}
+
+//replayRequest returns the stored verdict of the event when the request is the same event sent again, the event id
+//alone isn't trusted, since returning the verdict of another user's event would give away where they were
+func (d *Detector) replayRequest(ctx context.Context, existing *model.Record, request *model.EventRequestValidated) (*Verdict, error) {
+	if existing.UserName != request.Username || existing.Timestamp != request.UnixTimestamp ||
+		!net.ParseIP(existing.IP).Equal(net.ParseIP(request.IPAddress)) {
+		return nil, ErrEventConflict
+	}
+	return d.replay(ctx, existing)
+}
+
+//lockUser takes the user's lock in the process, and in the store if it can lock users across processes
+func (d *Detector) lockUser(ctx context.Context, user string) (func(), error) {
+	unlock := d.locks.lock(user)
+	if d.locker == nil {
+		return unlock, nil
+	}
+	unlockStore, err := d.locker.LockUser(ctx, user)
+	if err != nil {
+		unlock()
+		return nil, errors.Wrap(err, "failed to lock user")
+	}
+	return func() {
+		unlockStore()
+		unlock()
+	}, nil
+}
+
+//reevaluate evaluates a stored event against its current neighbours and saves the new verdict. It returns the
+//notification for the alert outputs when the verdict changed, causedBy is the event that led to it.
+func (d *Detector) reevaluate(ctx context.Context, record *model.Record, causedBy string) (*notify.Notification, error) {
+	var previous *Verdict
+	if len(record.Verdict) > 0 {
+		stored, err := d.replay(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		previous = stored
+	}
+
+	verdict, err := d.evaluateStored(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if d.notifier == nil || (previous != nil && !changed(previous, verdict)) {
+		return nil, nil
+	}
+
+	notification := &notify.Notification{
+		Type:     notify.VerdictChanged,
+		UserName: record.UserName,
+		EventID:  record.EventID,
+		CausedBy: causedBy,
+		Current:  verdict.Response(),
+	}
+	if previous != nil {
+		notification.Previous = previous.Response()
+	}
+	return notification, nil
+}
+
+//changed is true when the verdicts differ in a way anyone acting on them would care about, whether the event is
+//suspicious, its risk score, or which rules triggered
+func changed(previous, current *Verdict) bool {
+	if previous.Suspicious() != current.Suspicious() {
+		return true
+	}
+	if previous.Risk == nil || current.Risk == nil || previous.Risk.Score != current.Risk.Score {
+		return true
+	}
+	return triggered(previous) != triggered(current)
+}
+
+//triggered lists the codes and legs of the findings that triggered, for comparing verdicts
+func triggered(verdict *Verdict) string {
+	var codes []string
+	for _, f := range verdict.Findings {
+		if f.Triggered && !f.Suppressed {
+			codes = append(codes, string(f.Leg)+":"+f.reason().Code)
+		}
+	}
+	return strings.Join(codes, ",")
+}
+
+//Enrich looks up the geoip information for the request and builds the record that will be stored, with the ip
+//address in its canonical form. Non routable addresses and addresses geoip has no location for are stored with an
+//unknown location rather than failing the event.
+func (d *Detector) Enrich(request *model.EventRequestValidated) (*model.Record, error) {
+	ip, err := geoip.ParseIP(request.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	anonymity, err := d.anonymity(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := d.network(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	class := geoip.Classify(ip)
+	geo, err := d.locate(ip, class)
+	if err != nil {
+		return nil, err
+	}
+
+	record := model.NewRecord(request.Username,
+		request.EventID,
+		request.UnixTimestamp,
+		ip.String(),
+		anonymity,
+		network,
+		geo)
+	record.IPClass = string(class)
+	return record, nil
+}
+
+//locate looks up the location of the ip. Trusted networks come first, since they can place private addresses
+//and override what geoip thinks, after that only public addresses are worth asking geoip about.
+func (d *Detector) locate(ip net.IP, class geoip.IPClass) (model.Geo, error) {
+	if d.trusted != nil {
+		if network := d.trusted.Lookup(ip); network != nil {
+			return model.Geo{
+				Lat:    network.Latitude,
+				Lon:    network.Longitude,
+				Radius: network.AccuracyRadius,
+				Source: model.LocationTrustedNetwork,
+				Label:  network.Label,
+			}, nil
+		}
+	}
+	if class != geoip.Public {
+		return model.Geo{Source: model.LocationUnknown}, nil
+	}
+	location, err := d.service.Location(ip)
+	if err == geoip.ErrLocationUnknown {
+		return model.Geo{Source: model.LocationUnknown}, nil
+	}
+	if err != nil {
+		return model.Geo{}, errors.Wrap(err, "failed to lookup location")
+	}
+	return model.Geo{
+		Lat:       location.Latitude,
+		Lon:       location.Longitude,
+		Radius:    location.AccuracyRadius,
+		Source:    model.LocationGeoIP,
+		Precision: string(location.Precision),
+		Country:   location.Country,
+		TimeZone:  location.TimeZone,
+	}, nil
+}
+
+//anonymity classifies the ip, leaving it unchecked when there is no anonymous ip database to ask
+func (d *Detector) anonymity(ip net.IP) (model.Anonymity, error) {
+	anonymousIP, err := d.service.AnonymousIP(ip)
+	if err == geoip.ErrAnonymousIPUnavailable {
+		return model.Anonymity{}, nil
+	}
+	if err != nil {
+		return model.Anonymity{}, errors.Wrap(err, "failed to determine anonymous ip")
+	}
+	return model.Anonymity{
+		Checked:     true,
+		Anonymous:   d.service.IsAnonymous(anonymousIP),
+		VPN:         anonymousIP.IsAnonymousVPN,
+		Hosting:     anonymousIP.IsHostingProvider,
+		PublicProxy: anonymousIP.IsPublicProxy,
+		TorExitNode: anonymousIP.IsTorExitNode,
+	}, nil
+}
+
+//network looks up the autonomous system of the ip, leaving it empty when there is no asn database to ask
+func (d *Detector) network(ip net.IP) (model.Network, error) {
+	asn, err := d.service.ASN(ip)
+	if err == geoip.ErrASNUnavailable {
+		return model.Network{}, nil
+	}
+	if err != nil {
+		return model.Network{}, errors.Wrap(err, "failed to lookup asn")
+	}
+	return model.Network{ASN: asn.Number, Organization: asn.Organization}, nil
+}
+
+//Evaluate runs the registered rules for current against the given neighbours, either of which may be nil.
+//It only reads the network lists and the user's history from the store, so it can be used to score records that have
+//already been persisted. The risk score is worked out once every rule has run.
+//
+//A deny list match is a finding of its own. An allow list match still runs the rules, but anything they find is
+//suppressed, so the event isn't suspicious. So is anything found for a leg to or from a location the user has a
+//suppression for, from an alert marked a false positive.
+func (d *Detector) Evaluate(ctx context.Context, current, preceding, subsequent *model.Record) (*Verdict, error) {
+	verdict := &Verdict{Current: current}
+	if preceding != nil {
+		verdict.Preceding = newLeg(current, preceding)
+	}
+	if subsequent != nil {
+		verdict.Subsequent = newLeg(current, subsequent)
+	}
+
+	match, err := d.listMatch(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	verdict.NetworkList = match
+	if match != nil && match.List == model.DenyList {
+		verdict.add(denied(current, match))
+	}
+
+	err = d.suppress(ctx, verdict)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range d.rules {
+		findings, err := rule.Evaluate(ctx, verdict)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate rule %s", rule.Name())
+		}
+		verdict.add(findings...)
+	}
+
+	risk, err := d.scorer.Score(ctx, d.history, verdict)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to score event")
+	}
+	verdict.Risk = risk
+	return verdict, nil
+}
+
+//evaluateStored evaluates a record that is already in the store against its neighbours and saves the verdict.
+func (d *Detector) evaluateStored(ctx context.Context, record *model.Record) (*Verdict, error) {
+	preceding, err := d.store.PrecedingAccess(ctx, record.UserName, record.Timestamp, record.EventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve preceding access")
+	}
+
+	subsequent, err := d.store.SubsequentAccess(ctx, record.UserName, record.Timestamp, record.EventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve subsequent access")
+	}
+
+	verdict, err := d.Evaluate(ctx, record, preceding, subsequent)
+	if err != nil {
+		return nil, err
+	}
+	err = d.save(ctx, verdict)
+	if err != nil {
+		return nil, err
+	}
+	return verdict, nil
+}
+
+//save stores the verdict with its event. The event's alert is raised or revised before the verdict is saved, so if
+//that fails the event is evaluated again, alert and all, the next time it is posted.
+func (d *Detector) save(ctx context.Context, verdict *Verdict) error {
+	if d.alerts != nil {
+		err := d.raise(ctx, verdict)
+		if err != nil {
+			return err
+		}
+	}
+
+	record := verdict.Current
+	b, err := json.Marshal(verdict)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize verdict")
+	}
+	err = d.store.SaveVerdict(ctx, record.EventID, b)
+	if err != nil {
+		return errors.Wrap(err, "failed to store verdict")
+	}
+	record.Verdict = b
+	return nil
+}
+
+//replay returns the verdict that was stored with the event
+func (d *Detector) replay(ctx context.Context, record *model.Record) (*Verdict, error) {
+	if len(record.Verdict) == 0 {
+		// the event made it into the store but its verdict didn't, so finish the job
+		return d.evaluateStored(ctx, record)
+	}
+	verdict := &Verdict{}
+	err := json.Unmarshal(record.Verdict, verdict)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize stored verdict")
+	}
+	verdict.Current = record
+	return verdict, nil
+}
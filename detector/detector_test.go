@@ -0,0 +1,799 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/notify"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+)
+
+type fakeGeoIP struct {
+	locations map[string]*geoip.Location
+	anonymous map[string]*geoip.AnonymousIP
+	networks  map[string]*geoip.ASN
+}
+
+func (f *fakeGeoIP) AnonymousIP(ip net.IP) (*geoip.AnonymousIP, error) {
+	if f.anonymous == nil {
+		return nil, geoip.ErrAnonymousIPUnavailable
+	}
+	if anonymousIP, ok := f.anonymous[ip.String()]; ok {
+		return anonymousIP, nil
+	}
+	return &geoip.AnonymousIP{}, nil
+}
+
+func (f *fakeGeoIP) IsAnonymous(ip *geoip.AnonymousIP) bool {
+	return ip.IsAnonymous || ip.IsAnonymousVPN || ip.IsTorExitNode
+}
+
+func (f *fakeGeoIP) ASN(ip net.IP) (*geoip.ASN, error) {
+	if f.networks == nil {
+		return nil, geoip.ErrASNUnavailable
+	}
+	return f.networks[ip.String()], nil
+}
+
+func (f *fakeGeoIP) Location(ip net.IP) (*geoip.Location, error) {
+	if location, ok := f.locations[ip.String()]; ok {
+		return location, nil
+	}
+	return nil, geoip.ErrLocationUnknown
+}
+
+//fakeStore holds the preceding and subsequent accesses the test starts with plus whatever is put,
+//the neighbour lookups search all of them
+type fakeStore struct {
+	preceding    *model.Record
+	subsequent   *model.Record
+	put          []*model.Record
+	networks     []*model.NetworkListEntry
+	alerts       []*model.Alert
+	suppressions []*model.Suppression
+}
+
+func (f *fakeStore) records() []*model.Record {
+	var records []*model.Record
+	for _, r := range []*model.Record{f.preceding, f.subsequent} {
+		if r != nil {
+			records = append(records, r)
+		}
+	}
+	return append(records, f.put...)
+}
+
+func before(a *model.Record, timestamp int64, eventID string) bool {
+	return a.Timestamp < timestamp || (a.Timestamp == timestamp && a.EventID < eventID)
+}
+
+func (f *fakeStore) Put(ctx context.Context, record *model.Record) (int64, error) {
+	for _, r := range f.put {
+		if r.EventID == record.EventID {
+			return 0, store.ErrDuplicateEvent
+		}
+	}
+	f.put = append(f.put, record)
+	record.ID = int64(len(f.put))
+	return record.ID, nil
+}
+
+func (f *fakeStore) Event(ctx context.Context, eventID string) (*model.Record, error) {
+	for _, r := range f.records() {
+		if r.EventID == eventID {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeStore) SaveVerdict(ctx context.Context, eventID string, verdict []byte) error {
+	r, _ := f.Event(ctx, eventID)
+	r.Verdict = verdict
+	return nil
+}
+
+func (f *fakeStore) PrecedingAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	var nearest *model.Record
+	for _, r := range f.records() {
+		if r.UserName == user && before(r, timestamp, eventID) && (nearest == nil || before(nearest, r.Timestamp, r.EventID)) {
+			nearest = r
+		}
+	}
+	return nearest, nil
+}
+
+func (f *fakeStore) SubsequentAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	var nearest *model.Record
+	for _, r := range f.records() {
+		if r.UserName == user && r.EventID != eventID && !before(r, timestamp, eventID) && (nearest == nil || before(r, nearest.Timestamp, nearest.EventID)) {
+			nearest = r
+		}
+	}
+	return nearest, nil
+}
+
+func (f *fakeStore) Events(ctx context.Context, user string, filter model.EventFilter) ([]*model.Record, error) {
+	var events []*model.Record
+	for _, r := range f.records() {
+		if r.UserName != user || (filter.From != nil && r.Timestamp < *filter.From) || (filter.To != nil && r.Timestamp >= *filter.To) {
+			continue
+		}
+		if filter.After != nil && !before(&model.Record{Timestamp: filter.After.Timestamp, EventID: filter.After.EventID}, r.Timestamp, r.EventID) {
+			continue
+		}
+		events = append(events, r)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return before(events[i], events[j].Timestamp, events[j].EventID)
+	})
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
+
+func (f *fakeStore) PutNetwork(ctx context.Context, entry *model.NetworkListEntry) (int64, error) {
+	f.networks = append(f.networks, entry)
+	entry.ID = int64(len(f.networks))
+	return entry.ID, nil
+}
+
+func (f *fakeStore) DeleteNetwork(ctx context.Context, id int64) error {
+	return store.ErrNotFound
+}
+
+func (f *fakeStore) Networks(ctx context.Context, user string) ([]*model.NetworkListEntry, error) {
+	var entries []*model.NetworkListEntry
+	for _, entry := range f.networks {
+		if entry.Global() || entry.UserName == user {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (f *fakeStore) AllNetworks(ctx context.Context) ([]*model.NetworkListEntry, error) {
+	return f.networks, nil
+}
+
+func (f *fakeStore) PutAlert(ctx context.Context, alert *model.Alert) (int64, error) {
+	for _, a := range f.alerts {
+		if a.EventID == alert.EventID {
+			return 0, store.ErrDuplicateAlert
+		}
+	}
+	f.alerts = append(f.alerts, alert)
+	alert.ID = int64(len(f.alerts))
+	return alert.ID, nil
+}
+
+func (f *fakeStore) ReviseAlert(ctx context.Context, alert *model.Alert, now int64) (*model.Alert, error) {
+	for _, a := range f.alerts {
+		if a.EventID != alert.EventID || (a.Status != model.AlertOpen && a.Status != model.AlertAcknowledged) {
+			continue
+		}
+		if alert.Status == model.AlertResolved {
+			a.Status = model.AlertResolved
+		} else {
+			a.PrecedingEventID, a.SubsequentEventID, a.Score, a.Severity, a.Reasons =
+				alert.PrecedingEventID, alert.SubsequentEventID, alert.Score, alert.Severity, alert.Reasons
+		}
+		a.UpdatedAt = now
+		return a, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeStore) Alert(ctx context.Context, id int64) (*model.Alert, error) {
+	if id < 1 || id > int64(len(f.alerts)) {
+		return nil, nil
+	}
+	return f.alerts[id-1], nil
+}
+
+func (f *fakeStore) Alerts(ctx context.Context, filter model.AlertFilter) ([]*model.Alert, error) {
+	return f.alerts, nil
+}
+
+func (f *fakeStore) UpdateAlert(ctx context.Context, id int64, update *model.AlertUpdate, now int64) (*model.Alert, error) {
+	return nil, store.ErrNotFound
+}
+
+func (f *fakeStore) Suppressions(ctx context.Context, user string) ([]*model.Suppression, error) {
+	var result []*model.Suppression
+	for _, s := range f.suppressions {
+		if s.UserName == user {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+//the fake store's history is just the preceding access
+func (f *fakeStore) SeenCountry(ctx context.Context, user string, country string, timestamp int64, eventID string) (bool, error) {
+	return f.preceding != nil && f.preceding.Country == country, nil
+}
+
+func (f *fakeStore) SeenASN(ctx context.Context, user string, asn uint, timestamp int64, eventID string) (bool, error) {
+	return f.preceding != nil && f.preceding.ASN == asn, nil
+}
+
+func TestDetector_Detect(t *testing.T) {
+	service := &fakeGeoIP{
+		locations: map[string]*geoip.Location{
+			"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+		},
+		networks: map[string]*geoip.ASN{
+			"68.193.88.103": {Number: 6128, Organization: "Cablevision Systems Corp."},
+		},
+	}
+	storer := &fakeStore{
+		// tampa, four hours before
+		preceding: model.NewRecord("foo", "7c9ab6f0-0cc0-4b3f-9ef5-5b9e36f3a0a1", 1561600005-4*3600, "10.24.1.22", model.Anonymity{}, model.Network{}, model.Geo{Lat: 27.950575, Lon: -82.457176, Radius: 5, Source: model.LocationGeoIP}),
+		// los angeles, a minute after
+		subsequent: model.NewRecord("foo", "a0b1ccf2-94a7-4b0c-8a59-4a44b1f1d1c2", 1561600005+60, "10.24.1.23", model.Anonymity{}, model.Network{ASN: 7018, Organization: "AT&T Services, Inc."}, model.Geo{Lat: 34.0522, Lon: -118.2437, Radius: 5, Source: model.LocationGeoIP}),
+	}
+	d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+
+	request := &model.EventRequestValidated{
+		UnixTimestamp: 1561600005,
+		Username:      "foo",
+		EventID:       "05d86fca-825e-4515-86cc-7775a2d8047e",
+		IPAddress:     "68.193.88.103",
+	}
+	verdict, err := d.Detect(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, storer.put, 1)
+	require.NotEmpty(t, storer.put[0].Verdict)
+	require.True(t, verdict.Suspicious())
+	require.False(t, verdict.Preceding.Suspicious)
+	require.True(t, verdict.Subsequent.Suspicious)
+	require.Len(t, verdict.Findings, 2)
+
+	response := verdict.Response()
+	require.Equal(t, 40.7128, response.Current.Lat)
+	require.False(t, *response.TravelToCurrentGeoSuspicious)
+	require.True(t, *response.TravelFromCurrentGeoSuspicious)
+	require.Equal(t, "10.24.1.23", response.SubsequentIPAccess.IP)
+	require.Equal(t, Air, response.SubsequentIPAccess.Travel.Mode)
+	require.Equal(t, int64(60), response.SubsequentIPAccess.Travel.ElapsedSeconds)
+	require.True(t, response.SubsequentIPAccess.Travel.RequiredSpeedKmh > response.SubsequentIPAccess.Travel.LimitKmh)
+	require.Equal(t, &model.Network{ASN: 6128, Organization: "Cablevision Systems Corp."}, response.CurrentNetwork)
+	require.Equal(t, uint(7018), response.SubsequentIPAccess.Network.ASN)
+	require.Nil(t, response.PrecedingIPAccess.Network)
+	// impossible travel plus a network the user hasn't been seen on
+	require.Equal(t, DefaultWeights[SignalImpossibleTravel]+DefaultWeights[SignalNewASN], response.Risk.Score)
+
+	require.Len(t, response.Reasons, 2)
+	require.Equal(t, CodeFeasibleTravel, response.Reasons[0].Code)
+	require.Equal(t, "preceding", response.Reasons[0].Leg)
+	reason := response.Reasons[1]
+	require.Equal(t, CodeImpossibleTravel, reason.Code)
+	require.Equal(t, ImpossibleTravelRule, reason.Rule)
+	require.Equal(t, "subsequent", reason.Leg)
+	require.True(t, reason.Triggered)
+	require.Contains(t, reason.Message, "over the air limit")
+	require.Equal(t, uint16(5), reason.Travel.CurrentRadiusKm)
+	require.Equal(t, uint16(5), reason.Travel.AccessRadiusKm)
+
+	// los angeles is evaluated again now new york is before it, and is alerted on as well
+	require.Len(t, storer.alerts, 2)
+	require.Equal(t, "a0b1ccf2-94a7-4b0c-8a59-4a44b1f1d1c2", storer.alerts[1].EventID)
+	alert := storer.alerts[0]
+	require.Equal(t, request.EventID, alert.EventID)
+	require.Equal(t, "7c9ab6f0-0cc0-4b3f-9ef5-5b9e36f3a0a1", alert.PrecedingEventID)
+	require.Equal(t, "a0b1ccf2-94a7-4b0c-8a59-4a44b1f1d1c2", alert.SubsequentEventID)
+	require.Equal(t, response.Risk.Score, alert.Score)
+	require.Equal(t, model.SeverityHigh, alert.Severity)
+	require.Equal(t, model.AlertOpen, alert.Status)
+	require.Equal(t, model.Reasons(response.Reasons), alert.Reasons)
+
+	// the neighbours change, but posting the same event again must give back the original verdict
+	storer.subsequent = nil
+	replayed, err := d.Detect(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, storer.put, 1)
+	require.Equal(t, response, replayed.Response())
+	mapped := *request
+	mapped.IPAddress = "::ffff:68.193.88.103"
+	replayed, err = d.Detect(context.Background(), &mapped)
+	require.NoError(t, err)
+	require.Equal(t, response, replayed.Response())
+
+	// the event id of another event can't be used to read its verdict, or to store a different one under it
+	for _, change := range []func(r *model.EventRequestValidated){
+		func(r *model.EventRequestValidated) { r.Username = "bar" },
+		func(r *model.EventRequestValidated) { r.UnixTimestamp++ },
+		func(r *model.EventRequestValidated) { r.IPAddress = "68.193.88.104" },
+	} {
+		reused := *request
+		change(&reused)
+		_, err = d.Detect(context.Background(), &reused)
+		require.Equal(t, ErrEventConflict, err)
+	}
+	require.Len(t, storer.put, 1)
+}
+
+func TestDetector_EnrichAnonymity(t *testing.T) {
+	request := &model.EventRequestValidated{
+		UnixTimestamp: 1561600005,
+		Username:      "foo",
+		EventID:       "05d86fca-825e-4515-86cc-7775a2d8047e",
+		IPAddress:     "68.193.88.103",
+	}
+	locations := map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+	}
+
+	// without an anonymous ip database the event is still scored, just not classified
+	d := NewDetector(&fakeStore{}, &fakeGeoIP{locations: locations}, NewImpossibleTravel(DefaultFeasibility, 0))
+	verdict, err := d.Detect(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, verdict.Current.Checked)
+	require.Nil(t, verdict.Response().Anonymity)
+
+	service := &fakeGeoIP{locations: locations, anonymous: map[string]*geoip.AnonymousIP{
+		"68.193.88.103": {IsTorExitNode: true},
+	}}
+	d = NewDetector(&fakeStore{}, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	verdict, err = d.Detect(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, &model.Anonymity{Checked: true, Anonymous: true, TorExitNode: true}, verdict.Response().Anonymity)
+}
+
+func TestDetector_EnrichCanonicalIP(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103":         {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+		"2607:f8b0:4005:80a::e": {Latitude: 37.4192, Longitude: -122.0574, AccuracyRadius: 5},
+	}}
+	d := NewDetector(&fakeStore{}, service)
+
+	for address, canonical := range map[string]string{
+		"::ffff:68.193.88.103":        "68.193.88.103",
+		"2607:F8B0:4005:080A:0:0:0:E": "2607:f8b0:4005:80a::e",
+	} {
+		record, err := d.Enrich(&model.EventRequestValidated{Username: "foo", EventID: "05d86fca-825e-4515-86cc-7775a2d8047e", IPAddress: address})
+		require.NoError(t, err, address)
+		require.Equal(t, canonical, record.IP)
+		require.NotZero(t, record.Lat)
+	}
+}
+
+func TestDetector_DetectUnknownLocation(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+	}}
+
+	for address, class := range map[string]string{
+		"10.24.1.22":    "private",
+		"100.64.12.1":   "cgnat",
+		"127.0.0.1":     "loopback",
+		"203.0.113.200": "reserved",
+		"81.2.69.160":   "public", // public, but geoip doesn't know where it is
+	} {
+		storer := &fakeStore{
+			// los angeles, a minute before, which would be impossible from anywhere that isn't los angeles
+			preceding: model.NewRecord("foo", "7c9ab6f0-0cc0-4b3f-9ef5-5b9e36f3a0a1", 1561600005-60, "68.193.88.103", model.Anonymity{}, model.Network{}, model.Geo{Lat: 34.0522, Lon: -118.2437, Radius: 5, Source: model.LocationGeoIP}),
+		}
+		d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+		verdict, err := d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: 1561600005,
+			Username:      "foo",
+			EventID:       "05d86fca-825e-4515-86cc-7775a2d8047e",
+			IPAddress:     address,
+		})
+		require.NoError(t, err, address)
+		require.Equal(t, model.LocationUnknown, storer.put[0].Source, address)
+		require.False(t, verdict.Suspicious(), address)
+		require.True(t, verdict.Preceding.LocationUnknown, address)
+		require.Equal(t, "location unknown, travel not evaluated", verdict.Findings[0].Message, address)
+
+		response := verdict.Response()
+		require.Equal(t, model.LocationUnknown, response.Current.Source, address)
+		require.Equal(t, class, response.CurrentIPClass, address)
+		require.False(t, *response.TravelToCurrentGeoSuspicious, address)
+	}
+}
+
+type recordingNotifier struct {
+	notifications []*notify.Notification
+}
+
+func (r *recordingNotifier) Notify(ctx context.Context, notification *notify.Notification) error {
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+func TestDetector_DetectOutOfOrder(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},  // new york
+		"81.2.69.160":   {Latitude: 51.5074, Longitude: -0.1278, AccuracyRadius: 5},   // london
+		"12.34.56.78":   {Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5}, // los angeles
+	}}
+	storer := &fakeStore{}
+	notifier := &recordingNotifier{}
+	d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	d.Notify(notifier)
+	detect := func(eventID string, timestamp int64, address string) *Verdict {
+		verdict, err := d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: timestamp,
+			Username:      "foo",
+			EventID:       eventID,
+			IPAddress:     address,
+		})
+		require.NoError(t, err)
+		return verdict
+	}
+
+	// new york then los angeles six hours later is a flight
+	detect("e-100", 1561600005, "68.193.88.103")
+	require.False(t, detect("e-300", 1561600005+6*3600, "12.34.56.78").Suspicious())
+	require.Empty(t, notifier.notifications)
+
+	// london arrives late, three hours after new york, which makes both legs impossible
+	verdict := detect("e-200", 1561600005+3*3600, "81.2.69.160")
+	require.True(t, verdict.Preceding.Suspicious)
+	require.True(t, verdict.Subsequent.Suspicious)
+
+	require.Len(t, notifier.notifications, 1)
+	notification := notifier.notifications[0]
+	require.Equal(t, notify.VerdictChanged, notification.Type)
+	require.Equal(t, "e-300", notification.EventID)
+	require.Equal(t, "e-200", notification.CausedBy)
+	require.False(t, notification.Previous.Suspicious)
+	require.True(t, notification.Current.Suspicious)
+	require.Equal(t, "81.2.69.160", notification.Current.PrecedingIPAccess.IP)
+	// london and los angeles are alerted on, once each
+	require.Len(t, storer.alerts, 2)
+
+	// the stored verdict of los angeles was updated
+	replayed, err := d.Detect(context.Background(), &model.EventRequestValidated{UnixTimestamp: 1561600005 + 6*3600, Username: "foo", EventID: "e-300", IPAddress: "12.34.56.78"})
+	require.NoError(t, err)
+	require.True(t, replayed.Suspicious())
+	require.Equal(t, "e-200", replayed.Preceding.Access.EventID)
+}
+
+func TestDetector_DetectOutOfOrderPreceding(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5}, // new york
+		"81.2.69.160":   {Latitude: 51.5074, Longitude: -0.1278, AccuracyRadius: 5},  // london
+	}}
+	storer := &fakeStore{}
+	notifier := &recordingNotifier{}
+	d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	d.Notify(notifier)
+	detect := func(eventID string, timestamp int64, address string) *Verdict {
+		verdict, err := d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: timestamp,
+			Username:      "foo",
+			EventID:       eventID,
+			IPAddress:     address,
+		})
+		require.NoError(t, err)
+		return verdict
+	}
+
+	// london arrives first, then new york three hours before it, which is judged against london
+	require.False(t, detect("e-300", 1561600005+3*3600, "81.2.69.160").Suspicious())
+	verdict := detect("e-100", 1561600005, "68.193.88.103")
+	require.True(t, verdict.Subsequent.Suspicious)
+	require.Len(t, storer.alerts, 2)
+	notifier.notifications = nil
+
+	// new york again an hour before london is the last to arrive, it replaces london as the first new york's
+	// subsequent access, so the first new york is evaluated again and is no longer suspicious
+	require.True(t, detect("e-200", 1561600005+2*3600, "68.193.88.103").Suspicious())
+	replayed, err := d.Detect(context.Background(), &model.EventRequestValidated{UnixTimestamp: 1561600005, Username: "foo", EventID: "e-100", IPAddress: "68.193.88.103"})
+	require.NoError(t, err)
+	require.False(t, replayed.Suspicious())
+	require.Equal(t, "e-200", replayed.Subsequent.Access.EventID)
+	require.Equal(t, "e-100", storer.alerts[0].EventID)
+	require.Equal(t, model.AlertResolved, storer.alerts[0].Status)
+
+	var events []string
+	for _, notification := range notifier.notifications {
+		require.Equal(t, "e-200", notification.CausedBy)
+		events = append(events, notification.EventID)
+	}
+	require.Contains(t, events, "e-100")
+	require.False(t, notifier.notifications[len(notifier.notifications)-1].Current.Suspicious)
+}
+
+func TestDetector_DetectSlowNotifier(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5}, // new york
+		"81.2.69.160":   {Latitude: 51.5074, Longitude: -0.1278, AccuracyRadius: 5},  // london
+	}}
+	// a webhook that doesn't answer until the test is done with it
+	entered, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+	d := NewDetector(&fakeStore{}, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	d.Notify(notify.NewWebhook(server.URL, time.Minute))
+	detect := func(eventID string, timestamp int64, address string) (*Verdict, error) {
+		return d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: timestamp,
+			Username:      "foo",
+			EventID:       eventID,
+			IPAddress:     address,
+		})
+	}
+
+	_, err := detect("e-100", 1561600005, "68.193.88.103")
+	require.NoError(t, err)
+	_, err = detect("e-300", 1561600005+4*3600, "68.193.88.103")
+	require.NoError(t, err)
+
+	// london arrives late and changes the verdict of the event after it, the webhook is called and hangs
+	late := make(chan error)
+	go func() {
+		_, err := detect("e-200", 1561600005+3*3600, "81.2.69.160")
+		late <- err
+	}()
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the webhook wasn't called")
+	}
+
+	// the user's next event isn't held up by it
+	done := make(chan error)
+	go func() {
+		_, err := detect("e-400", 1561600005+5*3600, "68.193.88.103")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("detection waited for the webhook")
+	}
+
+	close(release)
+	require.NoError(t, <-late)
+}
+
+func TestDetector_DetectCentroid(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		// geoip only knows the address is somewhere in the united states
+		"68.193.88.103": {Latitude: 37.751, Longitude: -97.822, AccuracyRadius: 1000, Precision: geoip.PrecisionCentroid},
+	}}
+	storer := &fakeStore{
+		// new york, a minute before, which would be impossible from kansas
+		preceding: model.NewRecord("foo", "7c9ab6f0-0cc0-4b3f-9ef5-5b9e36f3a0a1", 1561600005-60, "81.2.69.160", model.Anonymity{}, model.Network{}, model.Geo{Lat: 40.7128, Lon: -74.0060, Radius: 5, Source: model.LocationGeoIP, Precision: "city"}),
+	}
+	d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	verdict, err := d.Detect(context.Background(), &model.EventRequestValidated{
+		UnixTimestamp: 1561600005,
+		Username:      "foo",
+		EventID:       "05d86fca-825e-4515-86cc-7775a2d8047e",
+		IPAddress:     "68.193.88.103",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "centroid", storer.put[0].Precision)
+	require.False(t, verdict.Suspicious())
+	require.True(t, verdict.Preceding.Centroid)
+	require.Equal(t, "location is only a country or region centroid, too imprecise to judge", verdict.Findings[0].Message)
+	require.Equal(t, "centroid", verdict.Response().Current.Precision)
+}
+
+func TestDetector_DetectSuppressed(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5},
+		"81.2.69.160":   {Latitude: 51.5142, Longitude: -0.0931, AccuracyRadius: 5},
+	}}
+	for address, suppressed := range map[string]bool{"68.193.88.103": true, "81.2.69.160": false} {
+		storer := &fakeStore{
+			// new york, an hour before, which is too far from both los angeles and london
+			preceding: model.NewRecord("foo", "7c9ab6f0-0cc0-4b3f-9ef5-5b9e36f3a0a1", 1561600005-3600, "74.125.21.100", model.Anonymity{}, model.Network{}, model.Geo{Lat: 40.7128, Lon: -74.0060, Radius: 5, Source: model.LocationGeoIP}),
+			// an earlier alert for travel between a few km outside new york and los angeles was a false positive, it
+			// doesn't cover travel between new york and london, and bar's don't count for foo
+			suppressions: []*model.Suppression{
+				{ID: 1, AlertID: 7, Leg: "subsequent", UserName: "foo", Lat: 40.75, Lon: -74.0, Radius: model.MinSuppressionRadiusKm,
+					NeighbourLat: 34.1, NeighbourLon: -118.3, NeighbourRadius: model.MinSuppressionRadiusKm},
+				{ID: 2, AlertID: 8, Leg: "preceding", UserName: "bar", Lat: 51.5142, Lon: -0.0931, Radius: model.MinSuppressionRadiusKm,
+					NeighbourLat: 40.7128, NeighbourLon: -74.0060, NeighbourRadius: model.MinSuppressionRadiusKm},
+			},
+		}
+		d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+		verdict, err := d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: 1561600005,
+			Username:      "foo",
+			EventID:       "05d86fca-825e-4515-86cc-7775a2d8047e",
+			IPAddress:     address,
+		})
+		require.NoError(t, err, address)
+		require.Equal(t, !suppressed, verdict.Suspicious(), address)
+		require.True(t, verdict.Findings[0].Triggered, address)
+		require.Equal(t, suppressed, verdict.Findings[0].Suppressed, address)
+		require.Equal(t, suppressed, verdict.Preceding.Suppression != nil, address)
+		if suppressed {
+			require.Equal(t, int64(7), verdict.Preceding.Suppression.AlertID)
+			require.Empty(t, storer.alerts)
+		}
+	}
+}
+
+func TestDetector_Timeline(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.102": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+		"68.193.88.103": {Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5},
+	}}
+	storer := &fakeStore{}
+	d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	// new york, los angeles an hour later, and los angeles again
+	for i, address := range []string{"68.193.88.102", "68.193.88.103", "68.193.88.103"} {
+		_, err := d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: 1561600005 + int64(i)*3600,
+			Username:      "foo",
+			EventID:       fmt.Sprintf("00000000-0000-4000-8000-%012d", i),
+			IPAddress:     address,
+		})
+		require.NoError(t, err)
+	}
+	// and back in new york the next day, stored without a verdict
+	storer.put = append(storer.put, model.NewRecord("foo", "00000000-0000-4000-8000-000000000003", 1561600005+86400,
+		"68.193.88.102", model.Anonymity{}, model.Network{}, model.Geo{Lat: 40.7128, Lon: -74.0060, Radius: 5, Source: model.LocationGeoIP}))
+
+	timeline, err := d.Timeline(context.Background(), "foo", model.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, timeline, 4)
+	require.Nil(t, timeline[0].Previous)
+	require.Nil(t, timeline[0].Travel)
+	require.Equal(t, 40.7128, timeline[0].Lat)
+
+	leg := timeline[1].Previous
+	require.Equal(t, "00000000-0000-4000-8000-000000000000", leg.EventID)
+	require.True(t, leg.Suspicious)
+	require.InDelta(t, 3935, leg.DistanceKm, 10)
+	require.Equal(t, int64(3600), leg.ElapsedSeconds)
+	require.InDelta(t, 3935, leg.SpeedKmh, 10)
+	require.InDelta(t, leg.SpeedKmh/KmPerMile, timeline[1].Speed, 0.001)
+	require.Equal(t, "air", timeline[1].Travel.Mode)
+
+	require.False(t, timeline[2].Previous.Suspicious)
+	require.Zero(t, timeline[2].Previous.DistanceKm)
+	// evaluated on the fly, a day is long enough to fly back
+	require.Equal(t, "00000000-0000-4000-8000-000000000002", timeline[3].Previous.EventID)
+	require.False(t, timeline[3].Previous.Suspicious)
+	require.NotNil(t, timeline[3].Travel)
+	require.Empty(t, storer.put[3].Verdict)
+
+	// a later page still gets the trip from the login before it
+	timeline, err = d.Timeline(context.Background(), "foo", model.EventFilter{
+		After: &model.EventPosition{Timestamp: 1561600005, EventID: "00000000-0000-4000-8000-000000000000"},
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, timeline, 1)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", timeline[0].EventID)
+	require.True(t, timeline[0].Previous.Suspicious)
+
+	timeline, err = d.Timeline(context.Background(), "bar", model.EventFilter{})
+	require.NoError(t, err)
+	require.Empty(t, timeline)
+}
+
+func TestDetector_DetectTrustedNetwork(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		// the vpn concentrator geolocates to a data center on the other coast
+		"68.193.88.103": {Latitude: 37.3541, Longitude: -121.9552, AccuracyRadius: 5},
+	}}
+	trusted, err := geoip.NewTrustedNetworks([]geoip.TrustedNetwork{
+		{CIDR: "10.24.0.0/16", Label: "atlanta office", Latitude: 33.7490, Longitude: -84.3880, AccuracyRadius: 5},
+		{CIDR: "68.193.88.0/24", Label: "atlanta vpn", Latitude: 33.7490, Longitude: -84.3880, AccuracyRadius: 5},
+	})
+	require.NoError(t, err)
+
+	for address, label := range map[string]string{"10.24.1.22": "atlanta office", "68.193.88.103": "atlanta vpn"} {
+		storer := &fakeStore{
+			// atlanta, a minute before
+			preceding: model.NewRecord("foo", "7c9ab6f0-0cc0-4b3f-9ef5-5b9e36f3a0a1", 1561600005-60, "74.125.21.100", model.Anonymity{}, model.Network{}, model.Geo{Lat: 33.7490, Lon: -84.3880, Radius: 5, Source: model.LocationGeoIP}),
+		}
+		d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+		d.Trust(trusted)
+		verdict, err := d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: 1561600005,
+			Username:      "foo",
+			EventID:       "05d86fca-825e-4515-86cc-7775a2d8047e",
+			IPAddress:     address,
+		})
+		require.NoError(t, err, address)
+		require.False(t, verdict.Suspicious(), address)
+
+		response := verdict.Response()
+		require.Equal(t, model.Geo{Lat: 33.7490, Lon: -84.3880, Radius: 5, Source: model.LocationTrustedNetwork, Label: label}, response.Current, address)
+	}
+}
+
+func TestDetector_NetworkLists(t *testing.T) {
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.103": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+		"81.2.69.160":   {Latitude: 51.5142, Longitude: -0.0931, AccuracyRadius: 5},
+	}}
+	detect := func(networks []*model.NetworkListEntry, user string, address string) *Verdict {
+		storer := &fakeStore{
+			networks: networks,
+			// los angeles, a minute before
+			preceding: model.NewRecord(user, "7c9ab6f0-0cc0-4b3f-9ef5-5b9e36f3a0a1", 1561600005-60, "74.125.21.100", model.Anonymity{}, model.Network{}, model.Geo{Lat: 34.0522, Lon: -118.2437, Radius: 5, Source: model.LocationGeoIP}),
+		}
+		d := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+		verdict, err := d.Detect(context.Background(), &model.EventRequestValidated{
+			UnixTimestamp: 1561600005,
+			Username:      user,
+			EventID:       "05d86fca-825e-4515-86cc-7775a2d8047e",
+			IPAddress:     address,
+		})
+		require.NoError(t, err)
+		return verdict
+	}
+
+	globalAllow := &model.NetworkListEntry{ID: 1, List: model.AllowList, CIDR: "68.193.0.0/16"}
+	userDeny := &model.NetworkListEntry{ID: 2, List: model.DenyList, CIDR: "68.0.0.0/8", UserName: "foo"}
+	globalDeny := &model.NetworkListEntry{ID: 3, List: model.DenyList, CIDR: "81.2.69.0/24"}
+	networks := []*model.NetworkListEntry{globalAllow, userDeny, globalDeny}
+
+	// the impossible trip from los angeles is on the global allow list for bar
+	verdict := detect(networks, "bar", "68.193.88.103")
+	require.False(t, verdict.Suspicious())
+	require.Equal(t, globalAllow, verdict.NetworkList)
+	require.True(t, verdict.Findings[0].Triggered)
+	require.True(t, verdict.Findings[0].Suppressed)
+	require.False(t, *verdict.Response().TravelToCurrentGeoSuspicious)
+
+	// but foo's own deny list beats the global allow list, even though it is less specific
+	verdict = detect(networks, "foo", "68.193.88.103")
+	require.True(t, verdict.Suspicious())
+	require.Equal(t, userDeny, verdict.NetworkList)
+	require.Equal(t, DenyListRule, verdict.Findings[0].Rule)
+	require.Equal(t, "68.193.88.103 is in 68.0.0.0/8 on the user deny list, entry 2", verdict.Findings[0].Message)
+	require.Equal(t, userDeny, verdict.Response().NetworkList)
+
+	// a deny list hit alerts even without any travel
+	verdict = detect([]*model.NetworkListEntry{globalDeny}, "bar", "81.2.69.160")
+	require.True(t, verdict.Response().Suspicious)
+
+	// the most specific range wins, and deny wins a tie
+	match, err := matchNetwork([]*model.NetworkListEntry{
+		{List: model.DenyList, CIDR: "68.0.0.0/8"},
+		{List: model.AllowList, CIDR: "68.193.88.0/24"},
+		{List: model.DenyList, CIDR: "68.193.88.0/24"},
+		{List: model.AllowList, CIDR: "68.193.0.0/16"},
+	}, "68.193.88.103")
+	require.NoError(t, err)
+	require.Equal(t, model.DenyList, match.List)
+	require.Equal(t, "68.193.88.0/24", match.CIDR)
+}
+
+type alwaysRule struct{}
+
+func (a alwaysRule) Name() string {
+	return "always"
+}
+
+func (a alwaysRule) Evaluate(ctx context.Context, verdict *Verdict) ([]Finding, error) {
+	return []Finding{{Rule: a.Name(), Triggered: true}}, nil
+}
+
+func TestDetector_Register(t *testing.T) {
+	d := NewDetector(&fakeStore{}, &fakeGeoIP{})
+	d.Register(alwaysRule{})
+
+	current := model.NewRecord("foo", "05d86fca-825e-4515-86cc-7775a2d8047e", 1561600005, "68.193.88.103", model.Anonymity{}, model.Network{}, model.Geo{Lat: 40.7128, Lon: -74.0060, Radius: 5, Source: model.LocationGeoIP})
+	verdict, err := d.Evaluate(context.Background(), current, nil, nil)
+	require.NoError(t, err)
+	require.True(t, verdict.Suspicious())
+	require.Nil(t, verdict.Response().TravelToCurrentGeoSuspicious)
+}
@@ -0,0 +1,73 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/pkg/errors"
+)
+
+//DenyListRule is the name reported in findings when the event's ip is on the deny list
+const DenyListRule = "deny_list"
+
+//listMatch finds the allow or deny list entry that applies to the current access, or nil if none do
+func (d *Detector) listMatch(ctx context.Context, current *model.Record) (*model.NetworkListEntry, error) {
+	if d.networks == nil {
+		return nil, nil
+	}
+	entries, err := d.networks.Networks(ctx, current.UserName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve network lists")
+	}
+	return matchNetwork(entries, current.IP)
+}
+
+//matchNetwork picks the entry that decides for ip. The user's own entries beat global ones, then the most
+//specific range wins, and deny wins a tie.
+func matchNetwork(entries []*model.NetworkListEntry, address string) (*model.NetworkListEntry, error) {
+	ip, err := geoip.ParseIP(address)
+	if err != nil {
+		return nil, err
+	}
+	var match *model.NetworkListEntry
+	matchBits := -1
+	for _, entry := range entries {
+		network, err := entry.Network()
+		if err != nil {
+			return nil, err
+		}
+		if !network.Contains(ip) {
+			continue
+		}
+		bits, _ := network.Mask.Size()
+		if match == nil || beats(entry, bits, match, matchBits) {
+			match, matchBits = entry, bits
+		}
+	}
+	return match, nil
+}
+
+func beats(entry *model.NetworkListEntry, bits int, match *model.NetworkListEntry, matchBits int) bool {
+	if entry.Global() != match.Global() {
+		return !entry.Global()
+	}
+	if bits != matchBits {
+		return bits > matchBits
+	}
+	return entry.List == model.DenyList && match.List != model.DenyList
+}
+
+//denied is the finding for an ip on the deny list
+func denied(current *model.Record, entry *model.NetworkListEntry) Finding {
+	scope := "global"
+	if !entry.Global() {
+		scope = "user"
+	}
+	return Finding{
+		Rule:      DenyListRule,
+		Code:      DenyListRule,
+		Triggered: true,
+		Message:   fmt.Sprintf("%s is in %s on the %s deny list, entry %d", current.IP, entry.CIDR, scope, entry.ID),
+	}
+}
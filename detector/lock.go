@@ -0,0 +1,44 @@
+package detector
+
+import (
+	"sync"
+)
+
+//userLocks serializes detection for each user within the process. A lock only stays in the map while someone
+//holds or is waiting for it, so the map doesn't grow with every user ever seen.
+type userLocks struct {
+	mu    sync.Mutex
+	locks map[string]*userLock
+}
+
+type userLock struct {
+	sync.Mutex
+	waiters int
+}
+
+func newUserLocks() *userLocks {
+	return &userLocks{locks: map[string]*userLock{}}
+}
+
+//lock blocks until the user's lock is held, the returned func releases it
+func (u *userLocks) lock(user string) func() {
+	u.mu.Lock()
+	l, ok := u.locks[user]
+	if !ok {
+		l = &userLock{}
+		u.locks[user] = l
+	}
+	l.waiters++
+	u.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		u.mu.Lock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(u.locks, user)
+		}
+		u.mu.Unlock()
+	}
+}
@@ -0,0 +1,41 @@
+package detector
+
+import (
+	"github.com/stretchr/testify/require"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestUserLocks(t *testing.T) {
+	locks := newUserLocks()
+	var wg sync.WaitGroup
+	inside := map[string]int{}
+	// counted rather than required, since only the test's own goroutine can stop it
+	overlaps := 0
+	var mu sync.Mutex
+	for i := 0; i < 50; i++ {
+		user := []string{"foo", "bar"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.lock(user)
+			defer unlock()
+			mu.Lock()
+			inside[user]++
+			if inside[user] > 1 {
+				overlaps++
+			}
+			mu.Unlock()
+
+			// give anyone who got in as well the chance to show up
+			runtime.Gosched()
+			mu.Lock()
+			inside[user]--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Zero(t, overlaps)
+	require.Empty(t, locks.locks)
+}
@@ -0,0 +1,145 @@
+package detector
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/pkg/errors"
+)
+
+//rescorePage is how many of a user's events are read from the store at a time
+const rescorePage = 500
+
+//ErrUsersUnsupported is returned when rescoring every user with a store that can't list them
+var ErrUsersUnsupported = errors.New("the configured store can't list its users, name the users to rescore")
+
+//Rescore evaluates the stored events of the users again, with the detector's current rules and settings, and reports
+//how the verdicts changed. Every user is rescored when users is empty, which needs a store that can list them.
+//
+//A user's events are walked in order and each is evaluated against the events either side of it, the one after it
+//only when its stored verdict was evaluated against one. Nothing is written unless write is set, then every changed
+//verdict is saved, raising the alerts that were missed and revising the open ones, which are resolved when their
+//event is no longer suspicious. The user is locked while their events are rescored.
+func (d *Detector) Rescore(ctx context.Context, users []string, write bool) (*model.RescoreReport, error) {
+	if len(users) == 0 {
+		lister, ok := d.store.(store.UserLister)
+		if !ok {
+			return nil, ErrUsersUnsupported
+		}
+		var err error
+		users, err = lister.Users(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list users")
+		}
+	}
+
+	report := &model.RescoreReport{Written: write, Added: []*model.RescoreChange{}, Removed: []*model.RescoreChange{}}
+	for _, user := range users {
+		err := d.rescoreUser(ctx, user, write, report)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to rescore %s", user)
+		}
+		report.Users++
+	}
+	return report, nil
+}
+
+func (d *Detector) rescoreUser(ctx context.Context, user string, write bool, report *model.RescoreReport) error {
+	unlock, err := d.lockUser(ctx, user)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// each event is rescored once the one after it has been read
+	var preceding, current *model.Record
+	filter := model.EventFilter{Limit: rescorePage}
+	for {
+		records, err := d.store.Events(ctx, user, filter)
+		if err != nil {
+			return errors.Wrap(err, "failed to retrieve events")
+		}
+		for _, record := range records {
+			if current != nil {
+				err = d.rescore(ctx, current, preceding, record, write, report)
+				if err != nil {
+					return err
+				}
+			}
+			preceding, current = current, record
+		}
+		if len(records) < rescorePage {
+			break
+		}
+		last := records[len(records)-1]
+		filter.After = &model.EventPosition{Timestamp: last.Timestamp, EventID: last.EventID}
+	}
+	if current == nil {
+		return nil
+	}
+	return d.rescore(ctx, current, preceding, nil, write, report)
+}
+
+//rescore evaluates one event again and adds it to the report if its verdict changed
+func (d *Detector) rescore(ctx context.Context, record, preceding, subsequent *model.Record, write bool, report *model.RescoreReport) error {
+	var previous *Verdict
+	if len(record.Verdict) > 0 {
+		previous = &Verdict{}
+		err := json.Unmarshal(record.Verdict, previous)
+		if err != nil {
+			return errors.Wrap(err, "failed to deserialize stored verdict")
+		}
+		previous.Current = record
+	}
+	// an event is evaluated against the neighbours it was last evaluated against, events that arrived in order never
+	// had the one after them, so only the configuration makes a difference
+	if previous == nil || previous.Subsequent == nil {
+		subsequent = nil
+	}
+
+	verdict, err := d.Evaluate(ctx, record, preceding, subsequent)
+	if err != nil {
+		return err
+	}
+	report.Events++
+	if previous != nil && !changed(previous, verdict) {
+		return nil
+	}
+	report.Changed++
+
+	wasSuspicious := previous != nil && previous.Suspicious()
+	switch {
+	case verdict.Suspicious() && !wasSuspicious:
+		report.Added = append(report.Added, rescoreChange(previous, verdict, verdict))
+	case !verdict.Suspicious() && wasSuspicious:
+		report.Removed = append(report.Removed, rescoreChange(previous, verdict, previous))
+	}
+	if !write {
+		return nil
+	}
+	return d.save(ctx, verdict)
+}
+
+//rescoreChange describes an alert that would be added or removed, with the reasons that triggered in suspicious
+func rescoreChange(previous, current, suspicious *Verdict) *model.RescoreChange {
+	record := current.Current
+	change := &model.RescoreChange{
+		EventID:   record.EventID,
+		UserName:  record.UserName,
+		Timestamp: record.Timestamp,
+		Reasons:   []model.Reason{},
+	}
+	if previous != nil && previous.Risk != nil {
+		change.PreviousScore = previous.Risk.Score
+	}
+	if current.Risk != nil {
+		change.Score = current.Risk.Score
+	}
+	for _, f := range suspicious.Findings {
+		if f.Triggered && !f.Suppressed {
+			change.Reasons = append(change.Reasons, f.reason())
+		}
+	}
+	return change
+}
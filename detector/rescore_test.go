@@ -0,0 +1,94 @@
+package detector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDetector_Rescore(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	storer := store.NewSqliteDb(db)
+	require.NoError(t, storer.Open())
+	defer storer.Close()
+
+	service := &fakeGeoIP{locations: map[string]*geoip.Location{
+		"68.193.88.102": {Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5},
+		"68.193.88.103": {Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5},
+	}}
+	ctx := context.Background()
+	alerts := func() []*model.Alert {
+		result, err := storer.Alerts(ctx, model.AlertFilter{Limit: 100})
+		require.NoError(t, err)
+		return result
+	}
+
+	// stored when flying coast to coast in an hour was thought possible
+	lax := NewDetector(storer, service, NewImpossibleTravel(Feasibility{GroundSpeedKmh: 120, ShortHopKm: 100, AirSpeedKmh: 100000}, 0))
+	for i := 0; i < 4; i++ {
+		for u, user := range []string{"foo", "bar"} {
+			verdict, err := lax.Detect(ctx, &model.EventRequestValidated{
+				UnixTimestamp: 1561600005 + int64(i)*3600,
+				Username:      user,
+				EventID:       fmt.Sprintf("00000000-0000-4000-8000-%06d%06d", u, i),
+				IPAddress:     fmt.Sprintf("68.193.88.%d", 102+i%2),
+			})
+			require.NoError(t, err)
+			require.False(t, verdict.Suspicious())
+		}
+	}
+	require.Empty(t, alerts())
+
+	strict := NewDetector(storer, service, NewImpossibleTravel(DefaultFeasibility, 0))
+	report, err := strict.Rescore(ctx, []string{"foo"}, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Users)
+	require.Equal(t, 4, report.Events)
+	require.Equal(t, 3, report.Changed)
+	require.False(t, report.Written)
+	require.Len(t, report.Added, 3)
+	require.Empty(t, report.Removed)
+	require.Equal(t, "foo", report.Added[0].UserName)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", report.Added[0].EventID)
+	require.NotEmpty(t, report.Added[0].Reasons)
+	require.Empty(t, alerts())
+
+	// every user, written back
+	report, err = strict.Rescore(ctx, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Users)
+	require.Equal(t, 8, report.Events)
+	require.Len(t, report.Added, 6)
+	require.True(t, report.Written)
+	require.Len(t, alerts(), 6)
+
+	report, err = strict.Rescore(ctx, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, 8, report.Events)
+	require.Zero(t, report.Changed)
+
+	// loosening the rules again removes them, and resolves bar's alerts
+	report, err = lax.Rescore(ctx, []string{"bar"}, true)
+	require.NoError(t, err)
+	require.Len(t, report.Removed, 3)
+	require.Empty(t, report.Added)
+	require.NotEmpty(t, report.Removed[0].Reasons)
+	require.Len(t, alerts(), 6)
+	for _, alert := range alerts() {
+		if alert.UserName == "bar" {
+			require.Equal(t, model.AlertResolved, alert.Status, alert.EventID)
+		} else {
+			require.Equal(t, model.AlertOpen, alert.Status, alert.EventID)
+		}
+	}
+
+	_, err = NewDetector(&fakeStore{}, service, NewImpossibleTravel(DefaultFeasibility, 0)).Rescore(ctx, nil, false)
+	require.Equal(t, ErrUsersUnsupported, err)
+}
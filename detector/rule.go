@@ -0,0 +1,54 @@
+package detector
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+)
+
+//Direction identifies which leg of travel a finding is about
+type Direction string
+
+const (
+	//Preceding is the leg from the preceding access to the current one
+	Preceding Direction = "preceding"
+	//Subsequent is the leg from the current access to the subsequent one
+	Subsequent Direction = "subsequent"
+)
+
+//Rule is a single check the detector runs against an event. Rules should not modify the verdict directly,
+//instead they return findings and the detector applies them.
+type Rule interface {
+	Name() string
+	Evaluate(ctx context.Context, verdict *Verdict) ([]Finding, error)
+}
+
+//Finding is the outcome of a rule. Leg is empty when the finding is about the event as a whole.
+//Suppressed findings triggered, but something, like an allow list entry, says not to alert on them.
+//Code is a machine readable outcome, rules that only have one outcome can leave it as the rule name.
+type Finding struct {
+	Rule       string    `json:"rule"`
+	Code       string    `json:"code,omitempty"`
+	Leg        Direction `json:"leg,omitempty"`
+	Triggered  bool      `json:"triggered"`
+	Suppressed bool      `json:"suppressed,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	// Travel is set by travel rules, with the limit the leg was measured against
+	Travel *model.Travel `json:"travel,omitempty"`
+}
+
+//reason converts the finding for the response. Findings stored before they had codes get the rule name.
+func (f Finding) reason() model.Reason {
+	code := f.Code
+	if code == "" {
+		code = f.Rule
+	}
+	return model.Reason{
+		Code:       code,
+		Rule:       f.Rule,
+		Leg:        string(f.Leg),
+		Triggered:  f.Triggered,
+		Suppressed: f.Suppressed,
+		Message:    f.Message,
+		Travel:     f.Travel,
+	}
+}
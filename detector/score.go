@@ -0,0 +1,183 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/pkg/errors"
+	"time"
+)
+
+//The signals that make up the risk score
+const (
+	SignalImpossibleTravel = "impossible_travel"
+	SignalAnonymousIP      = "anonymous_ip"
+	SignalNewCountry       = "new_country"
+	SignalNewASN           = "new_asn"
+	SignalUnusualHour      = "unusual_hour"
+	SignalDenyList         = "deny_list"
+)
+
+//Weights are the points each signal adds to the risk score when it fires, the total is capped at 100
+type Weights map[string]int
+
+//DefaultWeights put impossible travel and a deny list hit at or near the top of the scale on their own,
+//the other signals only get there in combination
+var DefaultWeights = Weights{
+	SignalImpossibleTravel: 70,
+	SignalAnonymousIP:      30,
+	SignalNewCountry:       20,
+	SignalNewASN:           10,
+	SignalUnusualHour:      10,
+	SignalDenyList:         100,
+}
+
+//Hours is a range of hours of the day, from Start up to but not including End. It wraps around midnight
+//when Start is after End.
+type Hours struct {
+	Start int
+	End   int
+}
+
+//Contains is true when hour is in the range
+func (h Hours) Contains(hour int) bool {
+	if h.Start <= h.End {
+		return hour >= h.Start && hour < h.End
+	}
+	return hour >= h.Start || hour < h.End
+}
+
+//DefaultUnusualHours are the small hours, midnight to six in the morning
+var DefaultUnusualHours = Hours{Start: 0, End: 6}
+
+//Scorer combines the outcome of the rules with what is known about the event and the user's history into a
+//single risk score
+type Scorer struct {
+	Weights      Weights
+	UnusualHours Hours
+}
+
+//NewScorer creates a scorer. Signals missing from weights get their default weight.
+func NewScorer(weights Weights, unusualHours Hours) *Scorer {
+	merged := Weights{}
+	for name, weight := range DefaultWeights {
+		merged[name] = weight
+	}
+	for name, weight := range weights {
+		merged[name] = weight
+	}
+	return &Scorer{Weights: merged, UnusualHours: unusualHours}
+}
+
+//signal says whether it fired for the verdict, with a detail explaining the outcome
+type signal func(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (bool, string, error)
+
+//Score evaluates every signal for the verdict, history may be nil in which case the signals based on the
+//user's history don't fire
+func (s *Scorer) Score(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (*model.Risk, error) {
+	signals := []struct {
+		name     string
+		evaluate signal
+	}{
+		{SignalImpossibleTravel, impossibleTravel},
+		{SignalAnonymousIP, anonymousIP},
+		{SignalNewCountry, newCountry},
+		{SignalNewASN, newASN},
+		{SignalUnusualHour, s.unusualHour},
+		{SignalDenyList, denyList},
+	}
+	risk := &model.Risk{}
+	for _, signal := range signals {
+		fired, detail, err := signal.evaluate(ctx, history, verdict)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate signal %s", signal.name)
+		}
+		sig := model.Signal{Name: signal.name, Fired: fired, Weight: s.Weights[signal.name], Detail: detail}
+		if fired {
+			sig.Points = sig.Weight
+			risk.Score += sig.Points
+		}
+		risk.Signals = append(risk.Signals, sig)
+	}
+	if risk.Score > 100 {
+		risk.Score = 100
+	}
+	if risk.Score < 0 {
+		risk.Score = 0
+	}
+	return risk, nil
+}
+
+func impossibleTravel(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (bool, string, error) {
+	for _, f := range verdict.Findings {
+		if f.Rule == ImpossibleTravelRule && f.Triggered && !f.Suppressed {
+			return true, fmt.Sprintf("%s travel is impossible", f.Leg), nil
+		}
+	}
+	return false, "", nil
+}
+
+func anonymousIP(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (bool, string, error) {
+	a := verdict.Current.Anonymity
+	if !a.Checked {
+		return false, "anonymous ip database not configured", nil
+	}
+	return a.Anonymous || a.VPN || a.Hosting || a.PublicProxy || a.TorExitNode, "", nil
+}
+
+//newCountry only fires for users with an earlier event, everywhere is new on a user's first login
+func newCountry(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (bool, string, error) {
+	current := verdict.Current
+	if history == nil {
+		return false, "history not available", nil
+	}
+	if current.Country == "" || verdict.Preceding == nil {
+		return false, "", nil
+	}
+	seen, err := history.SeenCountry(ctx, current.UserName, current.Country, current.Timestamp, current.EventID)
+	if err != nil || seen {
+		return false, "", err
+	}
+	return true, fmt.Sprintf("first event from %s", current.Country), nil
+}
+
+//newASN only fires for users with an earlier event, like newCountry
+func newASN(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (bool, string, error) {
+	current := verdict.Current
+	if history == nil {
+		return false, "history not available", nil
+	}
+	if current.ASN == 0 || verdict.Preceding == nil {
+		return false, "", nil
+	}
+	seen, err := history.SeenASN(ctx, current.UserName, current.ASN, current.Timestamp, current.EventID)
+	if err != nil || seen {
+		return false, "", err
+	}
+	return true, fmt.Sprintf("first event from AS%d", current.ASN), nil
+}
+
+//unusualHour is judged in the local time of the event's location, so it needs a time zone from geoip
+func (s *Scorer) unusualHour(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (bool, string, error) {
+	current := verdict.Current
+	if current.TimeZone == "" {
+		return false, "time zone unknown", nil
+	}
+	location, err := time.LoadLocation(current.TimeZone)
+	if err != nil {
+		return false, fmt.Sprintf("unknown time zone %s", current.TimeZone), nil
+	}
+	local := time.Unix(current.Timestamp, 0).In(location)
+	if !s.UnusualHours.Contains(local.Hour()) {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("%s local time", local.Format("15:04")), nil
+}
+
+func denyList(ctx context.Context, history store.HistoryStorer, verdict *Verdict) (bool, string, error) {
+	if verdict.NetworkList == nil || verdict.NetworkList.List != model.DenyList {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("%s is on the deny list", verdict.NetworkList.CIDR), nil
+}
@@ -0,0 +1,71 @@
+package detector
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHours_Contains(t *testing.T) {
+	require.True(t, Hours{Start: 0, End: 6}.Contains(0))
+	require.True(t, Hours{Start: 0, End: 6}.Contains(5))
+	require.False(t, Hours{Start: 0, End: 6}.Contains(6))
+	// wraps around midnight
+	require.True(t, Hours{Start: 22, End: 5}.Contains(23))
+	require.True(t, Hours{Start: 22, End: 5}.Contains(2))
+	require.False(t, Hours{Start: 22, End: 5}.Contains(12))
+}
+
+func TestNewScorer(t *testing.T) {
+	scorer := NewScorer(Weights{SignalNewASN: 40}, DefaultUnusualHours)
+	require.Equal(t, 40, scorer.Weights[SignalNewASN])
+	require.Equal(t, DefaultWeights[SignalAnonymousIP], scorer.Weights[SignalAnonymousIP])
+}
+
+func TestScorer_Score(t *testing.T) {
+	scorer := NewScorer(nil, DefaultUnusualHours)
+	preceding := &model.Record{EventID: "e-100", UserName: "foo", Timestamp: 100, Network: model.Network{ASN: 6128}, Geo: model.Geo{Country: "US"}}
+	history := &fakeStore{preceding: preceding}
+
+	// 2019-06-27 05:46:45 in berlin, from a new country and network through a vpn
+	current := &model.Record{EventID: "e-200", UserName: "foo", Timestamp: 1561607205,
+		Anonymity: model.Anonymity{Checked: true, VPN: true},
+		Network:   model.Network{ASN: 3320},
+		Geo:       model.Geo{Country: "DE", TimeZone: "Europe/Berlin"},
+	}
+	verdict := &Verdict{Current: current, Preceding: newLeg(current, preceding)}
+	risk, err := scorer.Score(context.Background(), history, verdict)
+	require.NoError(t, err)
+	require.Equal(t, 70, risk.Score)
+	fired := map[string]int{}
+	for _, signal := range risk.Signals {
+		if signal.Fired {
+			fired[signal.Name] = signal.Points
+		}
+	}
+	require.Equal(t, map[string]int{SignalAnonymousIP: 30, SignalNewCountry: 20, SignalNewASN: 10, SignalUnusualHour: 10}, fired)
+
+	// impossible travel on top of that is capped at 100
+	verdict.add(Finding{Rule: ImpossibleTravelRule, Leg: Preceding, Triggered: true})
+	risk, err = scorer.Score(context.Background(), history, verdict)
+	require.NoError(t, err)
+	require.Equal(t, 100, risk.Score)
+
+	// without history, or on a user's first event, new country and network don't fire
+	verdict = &Verdict{Current: current}
+	risk, err = scorer.Score(context.Background(), nil, verdict)
+	require.NoError(t, err)
+	require.Equal(t, 40, risk.Score)
+	require.Equal(t, "history not available", risk.Signals[2].Detail)
+}
+
+func TestScorer_ScoreDenyList(t *testing.T) {
+	scorer := NewScorer(nil, DefaultUnusualHours)
+	verdict := &Verdict{Current: &model.Record{}, NetworkList: &model.NetworkListEntry{List: model.DenyList, CIDR: "198.51.100.0/24"}}
+	risk, err := scorer.Score(context.Background(), nil, verdict)
+	require.NoError(t, err)
+	require.Equal(t, 100, risk.Score)
+	require.Equal(t, "anonymous ip database not configured", risk.Signals[1].Detail)
+	require.Equal(t, "time zone unknown", risk.Signals[4].Detail)
+}
@@ -0,0 +1,46 @@
+package detector
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/pkg/errors"
+)
+
+//suppress looks up the user's suppressions and marks every leg whose two ends are the pair of locations of one. The
+//findings for those legs are suppressed as they are added to the verdict.
+func (d *Detector) suppress(ctx context.Context, verdict *Verdict) error {
+	if d.alerts == nil || (verdict.Preceding == nil && verdict.Subsequent == nil) {
+		return nil
+	}
+	suppressions, err := d.alerts.Suppressions(ctx, verdict.Current.UserName)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve suppressions")
+	}
+	for _, leg := range []*Leg{verdict.Preceding, verdict.Subsequent} {
+		if leg != nil {
+			leg.Suppression = covering(suppressions, verdict.Current, leg.Access)
+		}
+	}
+	return nil
+}
+
+//covering returns the first suppression with one of the records inside each of its circles, in either direction, or
+//nil
+func covering(suppressions []*model.Suppression, a, b *model.Record) *model.Suppression {
+	if !a.Known() || !b.Known() {
+		return nil
+	}
+	for _, s := range suppressions {
+		near := inside(a, s.Lat, s.Lon, s.Radius) && inside(b, s.NeighbourLat, s.NeighbourLon, s.NeighbourRadius)
+		reversed := inside(b, s.Lat, s.Lon, s.Radius) && inside(a, s.NeighbourLat, s.NeighbourLon, s.NeighbourRadius)
+		if near || reversed {
+			return s
+		}
+	}
+	return nil
+}
+
+//inside is true when the record was located within radius km of the point
+func inside(record *model.Record, lat, lon float64, radius uint16) bool {
+	return calculateDistance(lat, lon, record.Lat, record.Lon) <= float64(radius)
+}
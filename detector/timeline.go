@@ -0,0 +1,85 @@
+package detector
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/pkg/errors"
+)
+
+//Timeline lists the user's logins matching the filter, each with the trip from the login before it, including for
+//the first login on the page. Trips are taken from the stored verdicts. A login whose verdict is missing, or was
+//judged against a different login than the one now before it, is evaluated again, without storing anything.
+func (d *Detector) Timeline(ctx context.Context, user string, filter model.EventFilter) ([]*model.TimelineEvent, error) {
+	records, err := d.store.Events(ctx, user, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve events")
+	}
+	timeline := []*model.TimelineEvent{}
+	if len(records) == 0 {
+		return timeline, nil
+	}
+
+	previous, err := d.store.PrecedingAccess(ctx, user, records[0].Timestamp, records[0].EventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve preceding access")
+	}
+	for _, record := range records {
+		event, err := d.timelineEvent(ctx, record, previous)
+		if err != nil {
+			return nil, err
+		}
+		timeline = append(timeline, event)
+		previous = record
+	}
+	return timeline, nil
+}
+
+func (d *Detector) timelineEvent(ctx context.Context, record, previous *model.Record) (*model.TimelineEvent, error) {
+	event := &model.TimelineEvent{
+		EventID: record.EventID,
+		IPAccess: model.IPAccess{
+			Geo:       record.Geo,
+			Network:   network(record),
+			IP:        record.IP,
+			IPClass:   record.IPClass,
+			Timestamp: record.Timestamp,
+		},
+	}
+	if previous == nil {
+		return event, nil
+	}
+
+	verdict, err := d.previousLeg(ctx, record, previous)
+	if err != nil {
+		return nil, err
+	}
+	leg := verdict.Preceding
+	event.Previous = &model.TimelineLeg{
+		EventID:         previous.EventID,
+		DistanceKm:      leg.DistanceKm,
+		ElapsedSeconds:  leg.ElapsedSeconds,
+		SpeedKmh:        leg.SpeedKmh,
+		Suspicious:      leg.Suspicious,
+		LocationUnknown: leg.LocationUnknown,
+	}
+	event.Travel = verdict.travel(Preceding)
+	event.Speed = leg.SpeedKmh / KmPerMile
+	return event, nil
+}
+
+//previousLeg returns a verdict with the trip from previous to record as its preceding leg
+func (d *Detector) previousLeg(ctx context.Context, record, previous *model.Record) (*Verdict, error) {
+	if len(record.Verdict) > 0 {
+		verdict := &Verdict{}
+		err := json.Unmarshal(record.Verdict, verdict)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to deserialize stored verdict")
+		}
+		if verdict.Preceding != nil && verdict.Preceding.Access.EventID == previous.EventID {
+			verdict.Current = record
+			return verdict, nil
+		}
+	}
+	return d.Evaluate(ctx, record, previous, nil)
+}
@@ -0,0 +1,202 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/umahmood/haversine"
+	"math"
+	"time"
+)
+
+//ImpossibleTravelRule is the name reported in findings by ImpossibleTravel
+const ImpossibleTravelRule = "impossible_travel"
+
+//The codes of the impossible travel findings
+const (
+	CodeImpossibleTravel = "impossible_travel"
+	CodeFeasibleTravel   = "feasible_travel"
+	CodeLocationUnknown  = "location_unknown"
+	CodeCentroid         = "centroid_location"
+	CodeImprecise        = "imprecise_location"
+)
+
+//KmPerMile converts miles to kilometres
+const KmPerMile = 1.609344
+
+//The travel modes a Feasibility limit can be based on
+const (
+	Ground = "ground"
+	Air    = "air"
+)
+
+//Feasibility is the travel model used to decide whether a trip could have been made. Trips up to ShortHopKm are
+//assumed to be made over the ground, anything longer by airliner, which also needs AirportOverhead for getting to
+//the airport, security, boarding and so on. All speeds are in km/h and distances in km.
+type Feasibility struct {
+	GroundSpeedKmh  float64
+	ShortHopKm      float64
+	AirSpeedKmh     float64
+	AirportOverhead time.Duration
+}
+
+//DefaultFeasibility is a highway for short hops, and a cruising airliner with an hour of overhead for long ones
+var DefaultFeasibility = Feasibility{
+	GroundSpeedKmh:  120,
+	ShortHopKm:      200,
+	AirSpeedKmh:     900,
+	AirportOverhead: time.Hour,
+}
+
+//Limit is the fastest average speed the model allows over distanceKm, and the mode of travel it assumed.
+//For air travel the overhead is included, so the limit is lower than the cruising speed. A flight never takes less
+//time than the longest short hop takes over the ground, so a longer trip is never allowed in less time than a shorter
+//one.
+func (f Feasibility) Limit(distanceKm float64) (float64, string) {
+	if distanceKm <= f.ShortHopKm {
+		return f.GroundSpeedKmh, Ground
+	}
+	hours := math.Max(distanceKm/f.AirSpeedKmh+f.AirportOverhead.Hours(), f.ShortHopKm/f.GroundSpeedKmh)
+	return distanceKm / hours, Air
+}
+
+//Assess measures the leg against the model. Either end could be anywhere within its accuracy radius, r1 for the
+//current access and r2 for the neighbour in km, so the trip is judged on the shortest distance between the two circles rather than between their centres.
+//Confidence is the share of the centre to centre distance left after taking the radii off, it is 1 when the
+//locations are exact and 0 when the circles overlap and the user may not have moved at all.
+func (f Feasibility) Assess(leg *Leg, r1, r2 uint16) *model.Travel {
+	minDistanceKm := math.Max(0, leg.DistanceKm-float64(r1)-float64(r2))
+	confidence := 1.0
+	if leg.DistanceKm > 0 {
+		confidence = minDistanceKm / leg.DistanceKm
+	}
+	limit, mode := f.Limit(minDistanceKm)
+	return &model.Travel{
+		CurrentRadiusKm:  r1,
+		AccessRadiusKm:   r2,
+		DistanceKm:       leg.DistanceKm,
+		MinDistanceKm:    minDistanceKm,
+		ElapsedSeconds:   leg.ElapsedSeconds,
+		RequiredSpeedKmh: calculateSpeed(minDistanceKm, time.Duration(leg.ElapsedSeconds)*time.Second),
+		LimitKmh:         limit,
+		Mode:             mode,
+		Confidence:       confidence,
+	}
+}
+
+//ImpossibleTravel flags legs where the user would have had to travel faster than the Feasibility model allows.
+//Legs where either accuracy radius is over MaxRadiusKm are too imprecise to judge and never trigger, zero means
+//there is no such limit.
+type ImpossibleTravel struct {
+	Feasibility Feasibility
+	MaxRadiusKm float64
+}
+
+//NewImpossibleTravel creates the impossible travel rule with the given travel model and max accuracy radius in km
+func NewImpossibleTravel(feasibility Feasibility, maxRadiusKm float64) *ImpossibleTravel {
+	return &ImpossibleTravel{Feasibility: feasibility, MaxRadiusKm: maxRadiusKm}
+}
+
+//Name satisfies the Rule interface
+func (i *ImpossibleTravel) Name() string {
+	return ImpossibleTravelRule
+}
+
+//Evaluate checks both legs of the verdict
+func (i *ImpossibleTravel) Evaluate(ctx context.Context, verdict *Verdict) ([]Finding, error) {
+	var findings []Finding
+	for _, direction := range []Direction{Preceding, Subsequent} {
+		leg := verdict.Leg(direction)
+		if leg == nil {
+			continue
+		}
+		finding := Finding{Rule: i.Name(), Leg: direction}
+		if leg.LocationUnknown {
+			finding.Code = CodeLocationUnknown
+			finding.Message = "location unknown, travel not evaluated"
+			findings = append(findings, finding)
+			continue
+		}
+		travel := i.Feasibility.Assess(leg, verdict.Current.Radius, leg.Access.Radius)
+		finding.Travel = travel
+		if leg.Centroid {
+			finding.Code = CodeCentroid
+			finding.Message = "location is only a country or region centroid, too imprecise to judge"
+		} else if radius := math.Max(float64(verdict.Current.Radius), float64(leg.Access.Radius)); i.MaxRadiusKm > 0 && radius > i.MaxRadiusKm {
+			finding.Code = CodeImprecise
+			finding.Message = fmt.Sprintf("accuracy radius of %.0f km is over %.0f km, too imprecise to judge", radius, i.MaxRadiusKm)
+		} else if travel.RequiredSpeedKmh > travel.LimitKmh {
+			finding.Triggered = true
+			finding.Code = CodeImpossibleTravel
+			finding.Message = fmt.Sprintf("travel of at least %.0f km in %s needs %.0f km/h, over the %s limit of %.0f km/h",
+				travel.MinDistanceKm, time.Duration(travel.ElapsedSeconds)*time.Second,
+				travel.RequiredSpeedKmh, travel.Mode, travel.LimitKmh)
+		} else {
+			finding.Code = CodeFeasibleTravel
+			finding.Message = fmt.Sprintf("travel of at least %.0f km in %s needs %.0f km/h, within the %s limit of %.0f km/h",
+				travel.MinDistanceKm, time.Duration(travel.ElapsedSeconds)*time.Second,
+				travel.RequiredSpeedKmh, travel.Mode, travel.LimitKmh)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+//newLeg builds the leg between the current access and one of its neighbours. There is no distance or speed
+//to speak of when either end has an unknown location.
+func newLeg(current, access *model.Record) *Leg {
+	if !current.Known() || !access.Known() {
+		return &Leg{Access: access, LocationUnknown: true}
+	}
+	distanceKm := calculateDistance(current.Lat, current.Lon, access.Lat, access.Lon)
+	elapsed := calculateElapsed(current.Timestamp, access.Timestamp)
+	return &Leg{
+		Access:         access,
+		DistanceKm:     distanceKm,
+		ElapsedSeconds: int64(elapsed.Seconds()),
+		SpeedKmh:       calculateSpeed(distanceKm, elapsed),
+		Centroid:       isCentroid(current) || isCentroid(access),
+	}
+}
+
+//isCentroid is true when geoip only knew the country or region of the access and gave us its centroid
+func isCentroid(access *model.Record) bool {
+	return access.Precision == string(geoip.PrecisionCentroid)
+}
+
+//calculateDistance is the great circle distance between two points in km
+func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	_, km := haversine.Distance(haversine.Coord{
+		Lat: lat1,
+		Lon: lon1,
+	}, haversine.Coord{
+		Lat: lat2,
+		Lon: lon2,
+	})
+	return km
+}
+
+//calculateElapsed is the time between two unix timestamps, always positive
+func calculateElapsed(ts1, ts2 int64) time.Duration {
+	t1 := time.Unix(ts1, 0)
+	t2 := time.Unix(ts2, 0)
+
+	// we want a positive duration, so always subtract the earlier time, from the later
+	if t1.Before(t2) {
+		return t2.Sub(t1)
+	}
+	return t1.Sub(t2)
+}
+
+//calculateSpeed is the average speed in km/h needed to cover distanceKm in elapsed. Timestamps only have second
+//precision, so two events in the same second are treated as a second apart rather than dividing by zero.
+func calculateSpeed(distanceKm float64, elapsed time.Duration) float64 {
+	if distanceKm == 0 {
+		return 0
+	}
+	if elapsed < time.Second {
+		elapsed = time.Second
+	}
+	return distanceKm / elapsed.Hours()
+}
@@ -0,0 +1,119 @@
+package detector
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFeasibility_Limit(t *testing.T) {
+	f := Feasibility{GroundSpeedKmh: 120, ShortHopKm: 200, AirSpeedKmh: 900, AirportOverhead: time.Hour}
+
+	limit, mode := f.Limit(150)
+	require.Equal(t, 120.0, limit)
+	require.Equal(t, Ground, mode)
+
+	// 1800 km is two hours in the air plus the hour of overhead
+	limit, mode = f.Limit(1800)
+	require.Equal(t, 600.0, limit)
+	require.Equal(t, Air, mode)
+
+	// the overhead matters less the further you go
+	limit, _ = f.Limit(9000)
+	require.InDelta(t, 818.18, limit, 0.01)
+
+	// just past the short hop a flight takes as long as the short hop over the ground, not less
+	limit, _ = f.Limit(200)
+	hours200 := 200 / limit
+	limit, mode = f.Limit(201)
+	require.Equal(t, Air, mode)
+	require.InDelta(t, 120.6, limit, 0.01)
+	require.True(t, 201/limit >= hours200)
+
+	// so the time a trip needs never goes down as it gets longer
+	previous := 0.0
+	for km := 1.0; km <= 2000; km++ {
+		limit, _ = f.Limit(km)
+		require.True(t, km/limit >= previous-1e-9, km)
+		previous = km / limit
+	}
+}
+
+func TestImpossibleTravel_Evaluate(t *testing.T) {
+	rule := NewImpossibleTravel(Feasibility{GroundSpeedKmh: 120, ShortHopKm: 200, AirSpeedKmh: 900, AirportOverhead: time.Hour}, 0)
+	// new york
+	current := &model.Record{Timestamp: 1561600005, Geo: model.Geo{Lat: 40.7128, Lon: -74.0060, Radius: 5}}
+
+	tests := []struct {
+		name       string
+		access     *model.Record
+		mode       string
+		suspicious bool
+	}{
+		// philadelphia is about 130 km away, which is over an hour on the ground even after the radii
+		{"short hop too fast", &model.Record{Timestamp: 1561600005 - 50*60, Geo: model.Geo{Lat: 39.9526, Lon: -75.1652, Radius: 5}}, Ground, true},
+		{"short hop", &model.Record{Timestamp: 1561600005 - 2*3600, Geo: model.Geo{Lat: 39.9526, Lon: -75.1652, Radius: 5}}, Ground, false},
+		// chicago is about 1150 km, an airliner does that in 1h17m but it takes 2h17m with the overhead
+		{"flight without overhead", &model.Record{Timestamp: 1561600005 - 2*3600, Geo: model.Geo{Lat: 41.8781, Lon: -87.6298, Radius: 5}}, Air, true},
+		{"flight", &model.Record{Timestamp: 1561600005 - 150*60, Geo: model.Geo{Lat: 41.8781, Lon: -87.6298, Radius: 5}}, Air, false},
+	}
+	for _, test := range tests {
+		verdict := &Verdict{Current: current, Preceding: newLeg(current, test.access)}
+		findings, err := rule.Evaluate(context.Background(), verdict)
+		require.NoError(t, err, test.name)
+		require.Len(t, findings, 1, test.name)
+		finding := findings[0]
+		require.Equal(t, test.suspicious, finding.Triggered, test.name)
+		require.Equal(t, test.mode, finding.Travel.Mode, test.name)
+		require.Equal(t, verdict.Preceding.DistanceKm, finding.Travel.DistanceKm, test.name)
+		require.Equal(t, 1561600005-test.access.Timestamp, finding.Travel.ElapsedSeconds, test.name)
+		require.Equal(t, finding.Travel.DistanceKm-10, finding.Travel.MinDistanceKm, test.name)
+		require.InDelta(t, finding.Travel.MinDistanceKm/(float64(finding.Travel.ElapsedSeconds)/3600), finding.Travel.RequiredSpeedKmh, 0.001, test.name)
+	}
+}
+
+func TestImpossibleTravel_EvaluateRadius(t *testing.T) {
+	rule := NewImpossibleTravel(DefaultFeasibility, 500)
+	// new york
+	current := &model.Record{Timestamp: 1561600005, Geo: model.Geo{Lat: 40.7128, Lon: -74.0060, Radius: 100}}
+
+	// philadelphia in an hour is too fast between the centres, but the circles are only about 30 km apart
+	access := &model.Record{Timestamp: 1561600005 - 3600, Geo: model.Geo{Lat: 39.9526, Lon: -75.1652, Radius: 0}}
+	verdict := &Verdict{Current: current, Preceding: newLeg(current, access)}
+	findings, err := rule.Evaluate(context.Background(), verdict)
+	require.NoError(t, err)
+	require.False(t, findings[0].Triggered)
+	require.InDelta(t, verdict.Preceding.DistanceKm-100, findings[0].Travel.MinDistanceKm, 0.001)
+	require.InDelta(t, findings[0].Travel.MinDistanceKm/verdict.Preceding.DistanceKm, findings[0].Travel.Confidence, 0.001)
+
+	// overlapping circles have no distance left and no confidence
+	access = &model.Record{Timestamp: 1561600005 - 60, Geo: model.Geo{Lat: 39.9526, Lon: -75.1652, Radius: 50}}
+	verdict = &Verdict{Current: current, Preceding: newLeg(current, access)}
+	findings, err = rule.Evaluate(context.Background(), verdict)
+	require.NoError(t, err)
+	require.False(t, findings[0].Triggered)
+	require.Equal(t, 0.0, findings[0].Travel.MinDistanceKm)
+	require.Equal(t, 0.0, findings[0].Travel.Confidence)
+	require.Equal(t, CodeFeasibleTravel, findings[0].Code)
+	require.Equal(t, uint16(100), findings[0].Travel.CurrentRadiusKm)
+	require.Equal(t, uint16(50), findings[0].Travel.AccessRadiusKm)
+
+	// los angeles in a minute is impossible, but a 1000 km radius is too imprecise to judge
+	access = &model.Record{Timestamp: 1561600005 - 60, Geo: model.Geo{Lat: 34.0522, Lon: -118.2437, Radius: 1000}}
+	verdict = &Verdict{Current: current, Preceding: newLeg(current, access)}
+	findings, err = rule.Evaluate(context.Background(), verdict)
+	require.NoError(t, err)
+	require.False(t, findings[0].Triggered)
+	require.Equal(t, CodeImprecise, findings[0].Code)
+	require.Contains(t, findings[0].Message, "too imprecise")
+	require.True(t, findings[0].Travel.RequiredSpeedKmh > findings[0].Travel.LimitKmh)
+}
+
+func TestCalculateSpeed(t *testing.T) {
+	require.Equal(t, 0.0, calculateSpeed(0, 0))
+	require.Equal(t, 100.0, calculateSpeed(100, time.Hour))
+	// events in the same second are a second apart
+	require.Equal(t, 3600.0, calculateSpeed(1, 0))
+}
@@ -0,0 +1,133 @@
+package detector
+
+import (
+	"github.com/edwardsb/secureworks/model"
+)
+
+//Verdict is the structured result of running the detector over an event. It is stored with the event as JSON,
+//Current is left out since that is the stored event itself.
+type Verdict struct {
+	Current    *model.Record `json:"-"`
+	Preceding  *Leg          `json:"preceding,omitempty"`
+	Subsequent *Leg          `json:"subsequent,omitempty"`
+	Findings   []Finding     `json:"findings,omitempty"`
+	// NetworkList is the allow or deny list entry the current access matched
+	NetworkList *model.NetworkListEntry `json:"networkList,omitempty"`
+	// Risk is the event's risk score
+	Risk *model.Risk `json:"risk,omitempty"`
+}
+
+//Leg is the travel between the current access and one of its neighbours
+type Leg struct {
+	Access *model.Record `json:"access"`
+	// since geoip2 returns accuracy radius in km, we keep distance in km and speed in km/h
+	DistanceKm     float64 `json:"distanceKm"`
+	ElapsedSeconds int64   `json:"elapsedSeconds"`
+	SpeedKmh       float64 `json:"speedKmh"`
+	Suspicious     bool    `json:"suspicious"`
+	// LocationUnknown is set when either end couldn't be located, so travel wasn't measured
+	LocationUnknown bool `json:"locationUnknown,omitempty"`
+	// Centroid is set when either end is only a country or region centroid, so the distance means little
+	Centroid bool `json:"centroid,omitempty"`
+	// Suppression is set when the leg's two ends are the pair of locations of a leg an analyst marked a false positive
+	// for the user
+	Suppression *model.Suppression `json:"suppression,omitempty"`
+}
+
+//Suspicious is true when any rule triggered, for either leg or for the event as a whole, and wasn't suppressed
+func (v *Verdict) Suspicious() bool {
+	for _, f := range v.Findings {
+		if f.Triggered && !f.Suppressed {
+			return true
+		}
+	}
+	return false
+}
+
+//Leg returns the leg for the given direction, or nil if there is no neighbour in that direction
+func (v *Verdict) Leg(direction Direction) *Leg {
+	switch direction {
+	case Preceding:
+		return v.Preceding
+	case Subsequent:
+		return v.Subsequent
+	}
+	return nil
+}
+
+func (v *Verdict) add(findings ...Finding) {
+	allowed := v.NetworkList != nil && v.NetworkList.List == model.AllowList
+	for _, f := range findings {
+		leg := v.Leg(f.Leg)
+		f.Suppressed = f.Triggered && (allowed || (leg != nil && leg.Suppression != nil))
+		if leg != nil && f.Triggered && !f.Suppressed {
+			leg.Suspicious = true
+		}
+		v.Findings = append(v.Findings, f)
+	}
+}
+
+//Response converts the verdict into the JSON payload returned by the web service
+func (v *Verdict) Response() *model.EventResponse {
+	response := &model.EventResponse{
+		Current:     v.Current.Geo,
+		Suspicious:  v.Suspicious(),
+		NetworkList: v.NetworkList,
+		Risk:        v.Risk,
+		Reasons:     []model.Reason{},
+	}
+	for _, f := range v.Findings {
+		response.Reasons = append(response.Reasons, f.reason())
+	}
+	response.CurrentNetwork = network(v.Current)
+	response.CurrentIPClass = v.Current.IPClass
+	if v.Current.Checked {
+		anonymity := v.Current.Anonymity
+		response.Anonymity = &anonymity
+	}
+	if v.Preceding != nil {
+		response.TravelToCurrentGeoSuspicious = assignBool(v.Preceding.Suspicious)
+		response.PrecedingIPAccess = v.Preceding.ipAccess(v.travel(Preceding))
+	}
+	if v.Subsequent != nil {
+		response.TravelFromCurrentGeoSuspicious = assignBool(v.Subsequent.Suspicious)
+		response.SubsequentIPAccess = v.Subsequent.ipAccess(v.travel(Subsequent))
+	}
+	return response
+}
+
+//travel is the impossible travel assessment of the leg, nil when it wasn't assessed
+func (v *Verdict) travel(direction Direction) *model.Travel {
+	for _, f := range v.Findings {
+		if f.Rule == ImpossibleTravelRule && f.Leg == direction {
+			return f.Travel
+		}
+	}
+	return nil
+}
+
+func (l *Leg) ipAccess(travel *model.Travel) *model.IPAccess {
+	return &model.IPAccess{
+		Geo:       l.Access.Geo,
+		Network:   network(l.Access),
+		Travel:    travel,
+		Speed:     l.SpeedKmh / KmPerMile,
+		IP:        l.Access.IP,
+		IPClass:   l.Access.IPClass,
+		Timestamp: l.Access.Timestamp,
+	}
+}
+
+//assignBool is a helper to set pointers to bools.
+func assignBool(b bool) *bool {
+	return &b
+}
+
+//network is the record's network for the response, or nil when it wasn't looked up
+func network(record *model.Record) *model.Network {
+	if record.ASN == 0 {
+		return nil
+	}
+	n := record.Network
+	return &n
+}
@@ -0,0 +1,95 @@
+package geoip
+
+import (
+	"github.com/pkg/errors"
+	"net"
+)
+
+//ParseIP parses an IPv4 or IPv6 address into its canonical form. IPv4-mapped IPv6 addresses, like ::ffff:10.0.0.1,
+//are the same host as the IPv4 address so they come back as a 4 byte IPv4 address. The String() of the result is
+//the canonical text form, dotted quad for IPv4 and RFC 5952 for IPv6, which is what we store and compare.
+func ParseIP(address string) (net.IP, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, errors.Errorf("invalid ip address %q", address)
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return v4, nil
+	}
+	return ip, nil
+}
+
+//IPClass says what kind of address an ip is, only public addresses can be geolocated
+type IPClass string
+
+const (
+	//Public addresses are routable on the internet
+	Public IPClass = "public"
+	//Private addresses are RFC 1918 and IPv6 unique local addresses
+	Private IPClass = "private"
+	//Loopback addresses are 127.0.0.0/8 and ::1
+	Loopback IPClass = "loopback"
+	//LinkLocal addresses are 169.254.0.0/16 and fe80::/10
+	LinkLocal IPClass = "link_local"
+	//CGNAT addresses are the RFC 6598 shared address space, 100.64.0.0/10, used behind carrier grade NAT
+	CGNAT IPClass = "cgnat"
+	//Reserved covers the rest of the special purpose ranges, unspecified, multicast, documentation, etc.
+	Reserved IPClass = "reserved"
+)
+
+var private = parseCIDRs("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7")
+
+var cgnat = parseCIDRs("100.64.0.0/10")
+
+//reserved are the IANA special purpose ranges that aren't covered by the other classes
+var reserved = parseCIDRs(
+	"0.0.0.0/8",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"240.0.0.0/4",
+	"64:ff9b:1::/48",
+	"100::/64",
+	"2001::/23",
+	"2001:db8::/32",
+)
+
+//Classify says whether ip is public, or which kind of non routable address it is
+func Classify(ip net.IP) IPClass {
+	switch {
+	case ip.IsLoopback():
+		return Loopback
+	case ip.IsLinkLocalUnicast():
+		return LinkLocal
+	case contains(private, ip):
+		return Private
+	case contains(cgnat, ip):
+		return CGNAT
+	case ip.IsUnspecified(), ip.IsMulticast(), contains(reserved, ip):
+		return Reserved
+	}
+	return Public
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func contains(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package geoip
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseIP(t *testing.T) {
+	tests := []struct {
+		address   string
+		canonical string
+		length    int
+	}{
+		{"68.193.88.103", "68.193.88.103", 4},
+		{"::ffff:68.193.88.103", "68.193.88.103", 4},
+		{"::FFFF:44c1:5867", "68.193.88.103", 4},
+		{"2001:0DB8:0000:0000:0000:0000:0000:0001", "2001:db8::1", 16},
+		{"2001:db8:0:0:1:0:0:1", "2001:db8::1:0:0:1", 16},
+		{"::1", "::1", 16},
+	}
+	for _, test := range tests {
+		ip, err := ParseIP(test.address)
+		require.NoError(t, err, test.address)
+		require.Equal(t, test.canonical, ip.String(), test.address)
+		require.Len(t, ip, test.length, test.address)
+	}
+
+	_, err := ParseIP("68.193.88")
+	require.Error(t, err)
+}
+
+func TestClassify(t *testing.T) {
+	tests := map[string]IPClass{
+		"68.193.88.103":         Public,
+		"2607:f8b0:4005:80a::e": Public,
+		"10.24.1.22":            Private,
+		"172.20.0.1":            Private,
+		"192.168.1.1":           Private,
+		"fd12:3456:789a::1":     Private,
+		"127.0.0.1":             Loopback,
+		"::1":                   Loopback,
+		"169.254.10.1":          LinkLocal,
+		"fe80::1":               LinkLocal,
+		"100.64.0.1":            CGNAT,
+		"100.127.255.254":       CGNAT,
+		"0.0.0.0":               Reserved,
+		"::":                    Reserved,
+		"224.0.0.1":             Reserved,
+		"192.0.2.10":            Reserved,
+		"2001:db8::1":           Reserved,
+		"255.255.255.255":       Reserved,
+		"::ffff:192.168.1.1":    Private,
+	}
+	for address, class := range tests {
+		ip, err := ParseIP(address)
+		require.NoError(t, err)
+		require.Equal(t, class, Classify(ip), address)
+	}
+}
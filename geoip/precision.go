@@ -0,0 +1,56 @@
+package geoip
+
+import (
+	"math"
+)
+
+//Precision is how precisely geoip located an ip. When it knows nothing better than the country it returns the
+//country's centroid, a point that can be thousands of km from the user, with a huge accuracy radius.
+type Precision string
+
+const (
+	//PrecisionCity locations are placed in a city
+	PrecisionCity Precision = "city"
+	//PrecisionRegion locations only know the state or region, the coordinates are somewhere in it
+	PrecisionRegion Precision = "region"
+	//PrecisionCentroid locations are the centroid of a country, or so imprecise they may as well be
+	PrecisionCentroid Precision = "centroid"
+)
+
+//CentroidRadiusKm is the accuracy radius from which a location is treated as a centroid,
+//maxmind uses 1000 km for country level locations
+const CentroidRadiusKm = 1000
+
+//centroids are coordinates geoip is known to hand out when it only knows the country,
+//like the farm in Kansas that is the default for the United States
+var centroids = []struct {
+	Latitude  float64
+	Longitude float64
+}{
+	{37.751, -97.822},
+	{38, -97},
+}
+
+//precision works out the precision of a location from its coordinates, accuracy radius and whether the
+//database named a city and a subdivision for it
+func precision(latitude, longitude float64, radius uint16, city, subdivision bool) Precision {
+	if radius >= CentroidRadiusKm || isCentroid(latitude, longitude) {
+		return PrecisionCentroid
+	}
+	if city {
+		return PrecisionCity
+	}
+	if subdivision {
+		return PrecisionRegion
+	}
+	return PrecisionCentroid
+}
+
+func isCentroid(latitude, longitude float64) bool {
+	for _, c := range centroids {
+		if math.Abs(c.Latitude-latitude) < 0.001 && math.Abs(c.Longitude-longitude) < 0.001 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package geoip
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPrecision(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		radius    uint16
+		city      bool
+		region    bool
+		precision Precision
+	}{
+		{"city", 40.7128, -74.0060, 5, true, true, PrecisionCity},
+		{"region", 40.7128, -74.0060, 200, false, true, PrecisionRegion},
+		{"country", 51.2993, 9.491, 200, false, false, PrecisionCentroid},
+		{"huge radius", 40.7128, -74.0060, 1000, true, true, PrecisionCentroid},
+		{"united states default", 37.751, -97.822, 200, true, true, PrecisionCentroid},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.precision, precision(test.latitude, test.longitude, test.radius, test.city, test.region), test.name)
+	}
+}
@@ -14,15 +14,19 @@ type GeoIP interface {
 	AnonymousIP(ip net.IP) (*AnonymousIP, error)
 	IsAnonymous(ip *AnonymousIP) bool
 	Location(ip net.IP) (*Location, error)
+	ASN(ip net.IP) (*ASN, error)
 }
 
-//Location hold location related data
+//Location hold location related data. Precision says whether the coordinates are a city, or only a region or
+//country centroid. Country is the iso code of the country.
 type Location struct {
 	AccuracyRadius uint16
 	Latitude       float64
 	Longitude      float64
 	MetroCode      uint
 	TimeZone       string
+	Country        string
+	Precision      Precision
 }
 
 //AnonymousIP holds bools for various types of anonymous ip classifications
@@ -34,34 +38,59 @@ type AnonymousIP struct {
 	IsTorExitNode     bool
 }
 
+//ASN holds the autonomous system, i.e. the network, an ip address belongs to
+type ASN struct {
+	Number       uint
+	Organization string
+}
+
+//ErrAnonymousIPUnavailable is returned by AnonymousIP when no Anonymous IP database is configured
+var ErrAnonymousIPUnavailable = errors.New("anonymous ip database not configured")
+
+//ErrLocationUnknown is returned by Location when the database has no location for the ip
+var ErrLocationUnknown = errors.New("location unknown")
+
+//ErrASNUnavailable is returned by ASN when no ASN database is configured
+var ErrASNUnavailable = errors.New("asn database not configured")
+
+//Paths are the locations of the maxmind databases. City is required, the others are optional
+//and the lookups backed by them return an Unavailable error when they aren't set.
+type Paths struct {
+	City        string
+	AnonymousIP string
+	ASN         string
+}
+
 //Service is the wrapper for geoip2 reader, and implements the GeoIP interface
 type Service struct {
-	reader *geoip2.Reader
-	path   string
+	reader          *geoip2.Reader
+	anonymousReader *geoip2.Reader
+	asnReader       *geoip2.Reader
+	paths           Paths
 }
 
 //NewService creates a new GeoIP2 backed Service
-func NewService(path string) *Service {
-	return &Service{path: path}
+func NewService(paths Paths) *Service {
+	return &Service{paths: paths}
 }
 
-//AnonymousIP checks GeoIP for Anonymous IPs. Currently this is only supported by the commercial versions of
-//GeoIP Anonymous IPs database. For now return false.
+//AnonymousIP checks the GeoIP2 Anonymous IP database, which is only available commercially. When it isn't
+//configured ErrAnonymousIPUnavailable is returned, so callers can carry on without the classification.
 func (g *Service) AnonymousIP(ip net.IP) (*AnonymousIP, error) {
-	//anonymousIP, err := g.reader.AnonymousIP(ip)
-	//if err != nil {
-	//	log.Println(err)
-	//	return nil, errors.Wrap(err, "failed to lookup anonymous ip")
-	//}
-	//return &AnonymousIP{
-	//	IsAnonymous:       anonymousIP.IsAnonymous,
-	//	IsAnonymousVPN:    anonymousIP.IsAnonymousVPN,
-	//	IsHostingProvider: anonymousIP.IsHostingProvider,
-	//	IsPublicProxy:     anonymousIP.IsPublicProxy,
-	//	IsTorExitNode:     anonymousIP.IsTorExitNode,
-	//}, nil
-
-	return &AnonymousIP{}, nil
+	if g.anonymousReader == nil {
+		return nil, ErrAnonymousIPUnavailable
+	}
+	anonymousIP, err := g.anonymousReader.AnonymousIP(ip)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup anonymous ip")
+	}
+	return &AnonymousIP{
+		IsAnonymous:       anonymousIP.IsAnonymous,
+		IsAnonymousVPN:    anonymousIP.IsAnonymousVPN,
+		IsHostingProvider: anonymousIP.IsHostingProvider,
+		IsPublicProxy:     anonymousIP.IsPublicProxy,
+		IsTorExitNode:     anonymousIP.IsTorExitNode,
+	}, nil
 }
 
 //IsAnonymous is just all the Anonymous type OR'd together. Some of these might be less anonymous than others
@@ -76,17 +105,49 @@ func (g *Service) Location(ip net.IP) (*Location, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup city")
 	}
+	// a miss isn't an error to geoip2, it just leaves everything zeroed, which would put the user on null island
+	if city.Location.Latitude == 0 && city.Location.Longitude == 0 {
+		return nil, ErrLocationUnknown
+	}
 	return &Location{
 		AccuracyRadius: city.Location.AccuracyRadius,
 		Latitude:       city.Location.Latitude,
 		Longitude:      city.Location.Longitude,
 		MetroCode:      city.Location.MetroCode,
 		TimeZone:       city.Location.TimeZone,
+		Country:        city.Country.IsoCode,
+		Precision: precision(city.Location.Latitude, city.Location.Longitude, city.Location.AccuracyRadius,
+			city.City.GeoNameID != 0, len(city.Subdivisions) > 0),
+	}, nil
+}
+
+//ASN looks up the autonomous system number and organization in the GeoLite2 ASN database. When it isn't
+//configured ErrASNUnavailable is returned.
+func (g *Service) ASN(ip net.IP) (*ASN, error) {
+	if g.asnReader == nil {
+		return nil, ErrASNUnavailable
+	}
+	asn, err := g.asnReader.ASN(ip)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup asn")
+	}
+	return &ASN{
+		Number:       asn.AutonomousSystemNumber,
+		Organization: asn.AutonomousSystemOrganization,
 	}, nil
 }
 
-//Close closes the underlying geoip reader
+//Close closes the underlying geoip readers
 func (g *Service) Close() error {
+	for _, reader := range []*geoip2.Reader{g.anonymousReader, g.asnReader} {
+		if reader == nil {
+			continue
+		}
+		err := reader.Close()
+		if err != nil {
+			return err
+		}
+	}
 	if g.reader != nil {
 		err := g.reader.Close()
 		if err != nil {
@@ -98,18 +159,47 @@ func (g *Service) Close() error {
 	return nil
 }
 
-//Open opens the underyling geoip reader
+//Open opens the underyling geoip readers
 func (g *Service) Open() error {
-	if len(g.path) == 0 {
+	if len(g.paths.City) == 0 {
 		return errors.New("empty path for geolite db")
 	}
 	if g.reader == nil {
-		reader, err := geoip2.Open(g.path)
+		reader, err := geoip2.Open(g.paths.City)
 		if err != nil {
 			return err
 		}
 		g.reader = reader
 	}
 	log.Println("opening geoip db")
+
+	reader, err := openOptional(g.anonymousReader, g.paths.AnonymousIP, "anonymous ip")
+	if err != nil {
+		return err
+	}
+	g.anonymousReader = reader
+
+	reader, err = openOptional(g.asnReader, g.paths.ASN, "asn")
+	if err != nil {
+		return err
+	}
+	g.asnReader = reader
 	return nil
 }
+
+//openOptional opens one of the optional databases, a missing path just disables the lookups backed by it
+func openOptional(reader *geoip2.Reader, path string, name string) (*geoip2.Reader, error) {
+	if reader != nil {
+		return reader, nil
+	}
+	if len(path) == 0 {
+		log.Printf("no %s db configured, %s lookups are disabled", name, name)
+		return nil, nil
+	}
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s db", name)
+	}
+	log.Printf("opening %s db", name)
+	return reader, nil
+}
@@ -0,0 +1,54 @@
+package geoip
+
+import (
+	"github.com/pkg/errors"
+	"net"
+)
+
+//TrustedNetwork pins a CIDR range, like an office NAT or a VPN concentrator, to a known location.
+//Private ranges are allowed, so internal addresses can resolve to the office they belong to.
+type TrustedNetwork struct {
+	CIDR      string  `json:"cidr" mapstructure:"cidr"`
+	Label     string  `json:"label" mapstructure:"label"`
+	Latitude  float64 `json:"lat" mapstructure:"lat"`
+	Longitude float64 `json:"lon" mapstructure:"lon"`
+	// AccuracyRadius is in km, the same as geoip
+	AccuracyRadius uint16 `json:"radius" mapstructure:"radius"`
+
+	network *net.IPNet
+}
+
+//TrustedNetworks is the table of trusted networks, it is consulted before geoip
+type TrustedNetworks struct {
+	networks []TrustedNetwork
+}
+
+//NewTrustedNetworks parses the CIDR of every network, an empty table is fine and matches nothing
+func NewTrustedNetworks(networks []TrustedNetwork) (*TrustedNetworks, error) {
+	t := &TrustedNetworks{}
+	for _, n := range networks {
+		_, network, err := net.ParseCIDR(n.CIDR)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trusted network %q", n.Label)
+		}
+		n.network = network
+		t.networks = append(t.networks, n)
+	}
+	return t, nil
+}
+
+//Lookup returns the most specific trusted network containing ip, or nil if there isn't one
+func (t *TrustedNetworks) Lookup(ip net.IP) *TrustedNetwork {
+	var match *TrustedNetwork
+	bits := -1
+	for i := range t.networks {
+		n := &t.networks[i]
+		if !n.network.Contains(ip) {
+			continue
+		}
+		if ones, _ := n.network.Mask.Size(); ones > bits {
+			match, bits = n, ones
+		}
+	}
+	return match
+}
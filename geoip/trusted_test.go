@@ -0,0 +1,39 @@
+package geoip
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTrustedNetworks_Lookup(t *testing.T) {
+	trusted, err := NewTrustedNetworks([]TrustedNetwork{
+		{CIDR: "10.0.0.0/8", Label: "corporate", Latitude: 33.7490, Longitude: -84.3880, AccuracyRadius: 1000},
+		{CIDR: "10.24.0.0/16", Label: "atlanta office", Latitude: 33.7490, Longitude: -84.3880, AccuracyRadius: 5},
+		{CIDR: "2001:db8:1::/48", Label: "vpn", Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 10},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"10.24.1.22":           "atlanta office",
+		"::ffff:10.24.1.22":    "atlanta office",
+		"10.1.1.1":             "corporate",
+		"2001:db8:1:2::1":      "vpn",
+		"68.193.88.103":        "",
+		"2001:db8:2::1":        "",
+		"::ffff:68.193.88.103": "",
+	}
+	for address, label := range tests {
+		ip, err := ParseIP(address)
+		require.NoError(t, err)
+		match := trusted.Lookup(ip)
+		if label == "" {
+			require.Nil(t, match, address)
+			continue
+		}
+		require.NotNil(t, match, address)
+		require.Equal(t, label, match.Label, address)
+	}
+
+	_, err = NewTrustedNetworks([]TrustedNetwork{{CIDR: "10.0.0.0/33", Label: "broken"}})
+	require.Error(t, err)
+}
@@ -10,6 +10,7 @@ require (
 	github.com/guregu/dynamo v1.2.1
 	github.com/hashicorp/go-multierror v1.0.0
 	github.com/jmoiron/sqlx v1.2.0
+	github.com/lib/pq v1.1.1
 	github.com/magiconair/properties v1.8.1 // indirect
 	github.com/mattn/go-sqlite3 v1.9.0
 	github.com/mitchellh/go-homedir v1.1.0
@@ -0,0 +1,111 @@
+package httpd
+
+import (
+	"errors"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//errNetworkListsUnsupported is returned by the network admin endpoints when the store has no network lists
+var errNetworkListsUnsupported = errors.New("the configured store does not support network lists")
+
+//adminRouter has the endpoints for managing the allow and deny lists, and for rescoring stored events
+func (h *HTTPServer) adminRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Route("/networks", func(r chi.Router) {
+		r.Use(h.requireNetworkStorer)
+		r.Get("/", h.listNetworks)
+		r.Post("/", h.createNetwork)
+		r.Delete("/{id}", h.deleteNetwork)
+	})
+	r.Post("/rescore", h.rescore)
+	return r
+}
+
+func (h *HTTPServer) requireNetworkStorer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.networks == nil {
+			renderStatus(w, r, http.StatusNotImplemented, errNetworkListsUnsupported)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+//listNetworks lists every entry, or with ?username= the entries that apply to that user
+func (h *HTTPServer) listNetworks(w http.ResponseWriter, r *http.Request) {
+	var entries []*model.NetworkListEntry
+	var err error
+	if username := r.URL.Query().Get("username"); len(username) > 0 {
+		entries, err = h.networks.Networks(r.Context(), username)
+	} else {
+		entries, err = h.networks.AllNetworks(r.Context())
+	}
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+	if entries == nil {
+		entries = []*model.NetworkListEntry{}
+	}
+	render.JSON(w, r, entries)
+}
+
+func (h *HTTPServer) createNetwork(w http.ResponseWriter, r *http.Request) {
+	entry := &model.NetworkListEntry{}
+	err := render.Bind(r, entry)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, err)
+		return
+	}
+	entry.ID = 0
+	entry.CreatedAt = time.Now().Unix()
+
+	_, err = h.networks.PutNetwork(r.Context(), entry)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+	render.Status(r, http.StatusCreated)
+	render.Render(w, r, entry)
+}
+
+func (h *HTTPServer) deleteNetwork(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, errors.New("invalid id"))
+		return
+	}
+	err = h.networks.DeleteNetwork(r.Context(), id)
+	if err == store.ErrNotFound {
+		renderStatus(w, r, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//rescore evaluates the stored events of the requested users again with the current configuration, and reports the
+//alerts that would be added or removed. The new verdicts are only saved when the request asks for it.
+func (h *HTTPServer) rescore(w http.ResponseWriter, r *http.Request) {
+	request := &model.RescoreRequest{}
+	err := render.Bind(r, request)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, err)
+		return
+	}
+	report, err := h.detector.Rescore(r.Context(), request.Users, request.Write)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+	render.Render(w, r, report)
+}
@@ -0,0 +1,113 @@
+package httpd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//newTestServer builds the routes on top of an in memory sqlite store, without starting to listen
+func newTestServer(t *testing.T) (*HTTPServer, *store.SqliteStorer) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	storer := store.NewSqliteDb(db)
+	require.NoError(t, storer.Open())
+
+	h := NewHTTPServer(detector.NewDetector(storer, nil), storer)
+	h.initRouter()
+	return h, storer
+}
+
+func serve(h *HTTPServer, method string, target string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdmin_Networks(t *testing.T) {
+	h, storer := newTestServer(t)
+	defer storer.Close()
+
+	w := serve(h, http.MethodPost, "/v1/admin/networks", `{"list": "allow", "cidr": "10.1.2.3/8", "comment": "corporate"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	created := &model.NetworkListEntry{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), created))
+	require.NotZero(t, created.ID)
+	require.Equal(t, "10.0.0.0/8", created.CIDR)
+
+	w = serve(h, http.MethodPost, "/v1/admin/networks", `{"list": "deny", "cidr": "198.51.100.0/24", "username": "foo"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	for _, body := range []string{`{"list": "maybe", "cidr": "10.0.0.0/8"}`, `{"list": "deny", "cidr": "10.0.0.0"}`} {
+		w = serve(h, http.MethodPost, "/v1/admin/networks", body)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	var entries []*model.NetworkListEntry
+	w = serve(h, http.MethodGet, "/v1/admin/networks", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	require.Len(t, entries, 2)
+
+	w = serve(h, http.MethodGet, "/v1/admin/networks?username=bar", "")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	require.Len(t, entries, 1)
+	require.Equal(t, "10.0.0.0/8", entries[0].CIDR)
+
+	w = serve(h, http.MethodDelete, fmt.Sprintf("/v1/admin/networks/%d", created.ID), "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = serve(h, http.MethodDelete, fmt.Sprintf("/v1/admin/networks/%d", created.ID), "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdmin_Rescore(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	// new york and los angeles an hour apart, alerted on as they came in
+	for i, user := range []string{"foo", "foo", "foo", "bar", "bar"} {
+		body := fmt.Sprintf(`{"username": %q, "unix_timestamp": %d, "event_uuid": "00000000-0000-4000-8000-%012d", "ip_address": "68.193.88.%d"}`,
+			user, 1561600005+i*3600, i, 10+i)
+		w := serve(h, http.MethodPost, "/v1/", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	rescore := func(body string) *model.RescoreReport {
+		w := serve(h, http.MethodPost, "/v1/admin/rescore", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		report := &model.RescoreReport{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), report))
+		return report
+	}
+	// nothing changed since, the verdicts are the same
+	report := rescore(`{"users": ["foo", "bar"]}`)
+	require.Equal(t, 2, report.Users)
+	require.Equal(t, 5, report.Events)
+	require.Zero(t, report.Changed)
+	require.False(t, report.Written)
+	require.Empty(t, report.Added)
+	require.Empty(t, report.Removed)
+
+	report = rescore(`{"users": ["foo", "nobody"], "write": true}`)
+	require.Equal(t, 2, report.Users)
+	require.Equal(t, 3, report.Events)
+	require.True(t, report.Written)
+
+	// the users have to be named, and there can't be too many of them
+	many, _ := json.Marshal(&model.RescoreRequest{Users: make([]string, model.MaxRescoreUsers+1)})
+	for _, body := range []string{`{"users": "foo"}`, `{}`, `{"write": true}`, `{"users": [], "write": true}`, string(many)} {
+		w := serve(h, http.MethodPost, "/v1/admin/rescore", body)
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
@@ -0,0 +1,203 @@
+package httpd
+
+import (
+	"errors"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//errAlertsUnsupported is returned by the alert endpoints when the store doesn't keep alerts
+var errAlertsUnsupported = errors.New("the configured store does not support alerts")
+
+//errAlertNotFound is returned for an alert id that doesn't exist
+var errAlertNotFound = errors.New("alert not found")
+
+//defaultPageLimit and maxPageLimit bound the size of a page of alerts or events
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+//alertPage is a page of alerts, NextCursor is passed as ?cursor= to get the next one and is empty on the last page
+type alertPage struct {
+	Alerts     []*model.Alert `json:"alerts"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+}
+
+//alertsRouter has the endpoints for the alerts raised for suspicious events
+func (h *HTTPServer) alertsRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Use(h.requireAlertStorer)
+	r.Get("/", h.listAlerts)
+	r.Get("/{id}", h.getAlert)
+	r.Patch("/{id}", h.updateAlert)
+	return r
+}
+
+func (h *HTTPServer) requireAlertStorer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.alerts == nil {
+			renderStatus(w, r, http.StatusNotImplemented, errAlertsUnsupported)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+//listAlerts lists alerts newest first. They can be filtered by ?username=, by event time with ?from= and ?to= as unix
+//timestamps, and by ?severity= and ?status=, which can be repeated or comma separated.
+func (h *HTTPServer) listAlerts(w http.ResponseWriter, r *http.Request) {
+	filter, err := alertFilter(r)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, err)
+		return
+	}
+	limit := filter.Limit
+	// ask for one more than the page holds, to know whether there is another page
+	filter.Limit++
+	alerts, err := h.alerts.Alerts(r.Context(), filter)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+
+	page := alertPage{Alerts: alerts}
+	if len(alerts) > limit {
+		page.Alerts = alerts[:limit]
+		page.NextCursor = strconv.FormatInt(page.Alerts[limit-1].ID, 10)
+	}
+	if page.Alerts == nil {
+		page.Alerts = []*model.Alert{}
+	}
+	render.JSON(w, r, page)
+}
+
+//getAlert gets an alert with its history
+func (h *HTTPServer) getAlert(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, errors.New("invalid id"))
+		return
+	}
+	alert, err := h.alerts.Alert(r.Context(), id)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+	if alert == nil {
+		renderStatus(w, r, http.StatusNotFound, errAlertNotFound)
+		return
+	}
+	render.Render(w, r, alert)
+}
+
+//updateAlert changes an alert's status or assignee, or adds a comment, and returns the alert with its history
+func (h *HTTPServer) updateAlert(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, errors.New("invalid id"))
+		return
+	}
+	update := &model.AlertUpdate{}
+	err = render.Bind(r, update)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, err)
+		return
+	}
+	alert, err := h.alerts.UpdateAlert(r.Context(), id, update, time.Now().Unix())
+	if err == store.ErrNotFound {
+		renderStatus(w, r, http.StatusNotFound, errAlertNotFound)
+		return
+	}
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+	render.Render(w, r, alert)
+}
+
+func alertFilter(r *http.Request) (model.AlertFilter, error) {
+	query := r.URL.Query()
+	filter := model.AlertFilter{
+		UserName:   query.Get("username"),
+		Severities: values(query["severity"]),
+		Statuses:   values(query["status"]),
+	}
+	if err := oneOf("severity", filter.Severities, model.Severities); err != nil {
+		return filter, err
+	}
+	if err := oneOf("status", filter.Statuses, model.AlertStatuses); err != nil {
+		return filter, err
+	}
+	var err error
+	if filter.From, err = optionalInt(query.Get("from"), "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = optionalInt(query.Get("to"), "to"); err != nil {
+		return filter, err
+	}
+	if cursor := query.Get("cursor"); len(cursor) > 0 {
+		filter.Before, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || filter.Before <= 0 {
+			return filter, errInvalidCursor
+		}
+	}
+	filter.Limit, err = pageLimit(query.Get("limit"))
+	return filter, err
+}
+
+//pageLimit parses the ?limit= of a paged endpoint, it defaults to defaultPageLimit
+func pageLimit(value string) (int, error) {
+	if len(value) == 0 {
+		return defaultPageLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > maxPageLimit {
+		return 0, errors.New("limit must be between 1 and " + strconv.Itoa(maxPageLimit))
+	}
+	return limit, nil
+}
+
+//values splits repeated and comma separated query values
+func values(params []string) []string {
+	var result []string
+	for _, param := range params {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); len(value) > 0 {
+				result = append(result, value)
+			}
+		}
+	}
+	return result
+}
+
+//oneOf checks every value is one of the allowed ones
+func oneOf(name string, values []string, allowed []string) error {
+	for _, value := range values {
+		found := false
+		for _, a := range allowed {
+			found = found || value == a
+		}
+		if !found {
+			return errors.New("invalid " + name + " " + value + ", must be one of " + strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func optionalInt(value string, name string) (*int64, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid " + name)
+	}
+	return &i, nil
+}
@@ -0,0 +1,162 @@
+package httpd
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func newAlertTestServer(t *testing.T) (*HTTPServer, *store.SqliteStorer) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	storer := store.NewSqliteDb(db)
+	require.NoError(t, storer.Open())
+
+	h := NewHTTPServer(detector.NewDetector(storer, cityGeoIP{}, detector.NewImpossibleTravel(detector.DefaultFeasibility, 0)), storer)
+	h.initRouter()
+	return h, storer
+}
+
+func listAlerts(t *testing.T, h *HTTPServer, query string) *alertPage {
+	w := serve(h, http.MethodGet, "/v1/alerts"+query, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	page := &alertPage{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), page))
+	return page
+}
+
+func TestAlerts_List(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	// an hour apart, alternating between new york and los angeles, so every event after the first raises an alert
+	for i, user := range []string{"foo", "foo", "foo", "foo", "bar", "bar"} {
+		body := fmt.Sprintf(`{"username": %q, "unix_timestamp": %d, "event_uuid": "00000000-0000-4000-8000-%012d", "ip_address": "68.193.88.%d"}`,
+			user, 1561600005+i*3600, i, 10+i)
+		w := serve(h, http.MethodPost, "/v1/", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	page := listAlerts(t, h, "")
+	require.Len(t, page.Alerts, 4)
+	require.Empty(t, page.NextCursor)
+	alert := page.Alerts[0]
+	require.Equal(t, "bar", alert.UserName)
+	require.Equal(t, "00000000-0000-4000-8000-000000000005", alert.EventID)
+	require.Equal(t, "00000000-0000-4000-8000-000000000004", alert.PrecedingEventID)
+	require.Equal(t, model.AlertOpen, alert.Status)
+	require.Equal(t, model.SeverityHigh, alert.Severity)
+	require.NotEmpty(t, alert.Reasons)
+
+	require.Len(t, listAlerts(t, h, "?username=foo").Alerts, 3)
+	require.Len(t, listAlerts(t, h, "?username=foo&from=1561607205&to=1561614405").Alerts, 2)
+	require.Len(t, listAlerts(t, h, "?severity=low,medium").Alerts, 0)
+	require.Len(t, listAlerts(t, h, "?severity=low&severity=high").Alerts, 4)
+	require.Len(t, listAlerts(t, h, "?status=open").Alerts, 4)
+	require.Len(t, listAlerts(t, h, "?status=resolved,false_positive").Alerts, 0)
+
+	// a page at a time
+	var seen []string
+	query := "?limit=3"
+	for {
+		page := listAlerts(t, h, query)
+		for _, a := range page.Alerts {
+			seen = append(seen, a.EventID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query = "?limit=3&cursor=" + page.NextCursor
+	}
+	require.Len(t, seen, 4)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", seen[3])
+
+	for _, query := range []string{"?from=yesterday", "?to=x", "?cursor=x", "?limit=0", "?limit=501", "?severity=severe", "?status=closed"} {
+		w := serve(h, http.MethodGet, "/v1/alerts"+query, "")
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestAlerts_Update(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	postEvent := func(i int, address string) *model.EventResponse {
+		body := fmt.Sprintf(`{"username": "foo", "unix_timestamp": %d, "event_uuid": "00000000-0000-4000-8000-%012d", "ip_address": %q}`,
+			1561600005+i*3600, i, address)
+		w := serve(h, http.MethodPost, "/v1/", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		response := &model.EventResponse{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), response))
+		return response
+	}
+	update := func(id int64, body string) *model.Alert {
+		w := serve(h, http.MethodPatch, fmt.Sprintf("/v1/alerts/%d", id), body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		alert := &model.Alert{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), alert))
+		return alert
+	}
+
+	// new york then los angeles an hour later
+	postEvent(0, "68.193.88.10")
+	require.True(t, postEvent(1, "68.193.88.11").Suspicious)
+	id := listAlerts(t, h, "").Alerts[0].ID
+
+	alert := update(id, `{"status": "acknowledged", "assignee": "alice", "actor": "alice"}`)
+	require.Equal(t, model.AlertAcknowledged, alert.Status)
+	require.Equal(t, "alice", alert.Assignee)
+	require.NotZero(t, alert.UpdatedAt)
+	alert = update(id, `{"comment": "the user is travelling with a vpn", "actor": "alice"}`)
+	require.Equal(t, model.AlertAcknowledged, alert.Status)
+	alert = update(id, `{"status": "false_positive", "assignee": "", "actor": "bob"}`)
+	require.Equal(t, model.AlertFalsePositive, alert.Status)
+	require.Empty(t, alert.Assignee)
+
+	w := serve(h, http.MethodGet, fmt.Sprintf("/v1/alerts/%d", id), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	alert = &model.Alert{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), alert))
+	require.Len(t, alert.History, 3)
+	require.Equal(t, model.AlertChange{ID: 1, AlertID: id, Actor: "alice", PreviousStatus: model.AlertOpen, Status: model.AlertAcknowledged,
+		Assignee: "alice", CreatedAt: alert.History[0].CreatedAt}, *alert.History[0])
+	require.Equal(t, "the user is travelling with a vpn", alert.History[1].Comment)
+	require.Equal(t, model.AlertAcknowledged, alert.History[1].PreviousStatus)
+	require.Equal(t, model.AlertAcknowledged, alert.History[1].Status)
+	require.Equal(t, "alice", alert.History[2].PreviousAssignee)
+	require.Equal(t, model.AlertFalsePositive, alert.History[2].Status)
+	require.Len(t, listAlerts(t, h, "?status=false_positive").Alerts, 1)
+
+	// back to new york, travel from los angeles is no longer alerted on
+	response := postEvent(2, "68.193.88.12")
+	require.False(t, response.Suspicious)
+	require.True(t, response.Reasons[0].Suppressed)
+	require.Len(t, listAlerts(t, h, "").Alerts, 1)
+
+	// reopened, it is again
+	update(id, `{"status": "open", "actor": "bob"}`)
+	require.True(t, postEvent(3, "68.193.88.13").Suspicious)
+	require.Len(t, listAlerts(t, h, "").Alerts, 2)
+
+	for body, code := range map[string]int{
+		`{"status": "acknowledged"}`:                 http.StatusBadRequest,
+		`{"actor": "alice"}`:                         http.StatusBadRequest,
+		`{"status": "closed", "actor": "alice"}`:     http.StatusBadRequest,
+		`{"status": "resolved", "actor": "alice"`:    http.StatusBadRequest,
+		`{"status": "resolved", "actor": "alice"} `:  http.StatusOK,
+		`{"comment": "still looking", "actor": "a"}`: http.StatusOK,
+	} {
+		w := serve(h, http.MethodPatch, fmt.Sprintf("/v1/alerts/%d", id), body)
+		require.Equal(t, code, w.Code, body)
+	}
+	require.Equal(t, http.StatusNotFound, serve(h, http.MethodPatch, "/v1/alerts/99", `{"comment": "?", "actor": "a"}`).Code)
+	require.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/v1/alerts/99", "").Code)
+	require.Equal(t, http.StatusBadRequest, serve(h, http.MethodGet, "/v1/alerts/x", "").Code)
+}
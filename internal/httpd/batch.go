@@ -0,0 +1,216 @@
+package httpd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/go-chi/render"
+	"io"
+	"log"
+	"net/http"
+)
+
+//maxBatchSize is the most events a batch can hold
+const maxBatchSize = 10000
+
+//maxBatchLine is the longest event a batch can have, a line of NDJSON or an element of an array
+const maxBatchLine = 64 * 1024
+
+//maxBatchBody is the most bytes a batch can have, enough for maxBatchSize events of a few hundred bytes each
+const maxBatchBody = 8 * 1024 * 1024
+
+//errEmptyBatch is returned for a batch without any events
+var errEmptyBatch = errors.New("the batch has no events")
+
+//errBatchTooLarge is returned for a batch with more than maxBatchSize events
+var errBatchTooLarge = fmt.Errorf("a batch can hold at most %d events", maxBatchSize)
+
+//batchHandler takes a JSON array of event requests, or an NDJSON stream of them with one per line, and detects all of
+//them. Every event is validated against the event request schema on its own, and gets a result of its own, so one
+//bad event doesn't fail the batch, even one that isn't valid JSON. The batch as a whole only fails when it can't be
+//split into events, which for an array means its brackets, braces and strings don't balance.
+func (h *HTTPServer) batchHandler(validator *EventRequestValidator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// a body without a length, or a longer one than it said, is cut off at the limit and fails to be read
+		if r.ContentLength > maxBatchBody {
+			renderStatus(w, r, http.StatusRequestEntityTooLarge, fmt.Errorf("a batch can be at most %d bytes", maxBatchBody))
+			return
+		}
+		payloads, err := readBatch(http.MaxBytesReader(w, r.Body, maxBatchBody))
+		if err == errBatchTooLarge {
+			renderStatus(w, r, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		if err != nil {
+			renderStatus(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		response := &model.BatchResponse{Results: make([]*model.BatchResult, len(payloads))}
+		var requests []*model.EventRequestValidated
+		var indexes []int
+		for i, payload := range payloads {
+			response.Results[i] = &model.BatchResult{Index: i}
+			request, err := validator.Validate(payload)
+			if err != nil {
+				response.Results[i].Error = err.Error()
+				continue
+			}
+			response.Results[i].EventID = request.EventID
+			requests = append(requests, request)
+			indexes = append(indexes, i)
+		}
+
+		verdicts, errs := h.detector.DetectBatch(r.Context(), requests)
+		for j, i := range indexes {
+			if errs[j] != nil {
+				response.Results[i].Error = errs[j].Error()
+				continue
+			}
+			response.Results[i].Verdict = verdicts[j].Response()
+		}
+		for _, result := range response.Results {
+			if len(result.Error) > 0 {
+				response.Failed++
+			} else {
+				response.Processed++
+			}
+		}
+		log.Printf("batch of %d events, %d processed, %d failed\n", len(payloads), response.Processed, response.Failed)
+		render.Render(w, r, response)
+	}
+}
+
+//readBatch splits the batch into the payloads of its events. A batch starting with [ is a JSON array, anything else
+//is NDJSON, where blank lines are skipped.
+func readBatch(body io.Reader) ([]json.RawMessage, error) {
+	reader := bufio.NewReader(body)
+	first, err := firstByte(reader)
+	if err == io.EOF {
+		return nil, errEmptyBatch
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var payloads []json.RawMessage
+	if first == '[' {
+		payloads, err = readArray(reader)
+	} else {
+		payloads, err = readLines(reader)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(payloads) == 0 {
+		return nil, errEmptyBatch
+	}
+	return payloads, nil
+}
+
+//firstByte peeks at the first byte that isn't white space
+func firstByte(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, reader.UnreadByte()
+		}
+	}
+}
+
+//readArray splits a JSON array into its elements without decoding them, so an element that isn't valid JSON fails
+//validation on its own, like a bad line of NDJSON does. Only an array whose brackets, braces and strings don't
+//balance, where it can't be told where one element ends and the next begins, or that has an element longer than
+//maxBatchLine, fails the batch.
+func readArray(reader *bufio.Reader) ([]json.RawMessage, error) {
+	// the opening bracket, which we already know is there
+	if _, err := reader.ReadByte(); err != nil {
+		return nil, err
+	}
+	var payloads []json.RawMessage
+	var element, open []byte
+	inString, escaped := false, false
+	add := func() error {
+		if len(payloads) == maxBatchSize {
+			return errBatchTooLarge
+		}
+		payloads = append(payloads, bytes.TrimSpace(element))
+		element = nil
+		return nil
+	}
+	for offset := 1; ; offset++ {
+		b, err := reader.ReadByte()
+		if err == io.EOF {
+			return nil, fmt.Errorf("event %d: unexpected end of the array", len(payloads))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(element) == maxBatchLine {
+			return nil, fmt.Errorf("event %d: longer than %d bytes", len(payloads), maxBatchLine)
+		}
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+		case b == '"':
+			inString = true
+		case b == '{' || b == '[':
+			open = append(open, b)
+		case b == ']' && len(open) == 0:
+			// the closing bracket, an empty array has no elements
+			if len(payloads) > 0 || len(bytes.TrimSpace(element)) > 0 {
+				if err := add(); err != nil {
+					return nil, err
+				}
+			}
+			return payloads, nil
+		case b == '}' || b == ']':
+			if len(open) == 0 || (open[len(open)-1] == '{') != (b == '}') {
+				return nil, fmt.Errorf("event %d: unexpected %c at offset %d", len(payloads), b, offset)
+			}
+			open = open[:len(open)-1]
+		case b == ',' && len(open) == 0:
+			if err := add(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		element = append(element, b)
+	}
+}
+
+func readLines(reader io.Reader) ([]json.RawMessage, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 4096), maxBatchLine)
+	var payloads []json.RawMessage
+	lines := 0
+	for scanner.Scan() {
+		lines++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if len(payloads) == maxBatchSize {
+			return nil, errBatchTooLarge
+		}
+		// the scanner reuses its buffer, so the line has to be copied
+		payloads = append(payloads, append(json.RawMessage{}, line...))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("line %d: %v", lines+1, err)
+	}
+	return payloads, nil
+}
@@ -0,0 +1,134 @@
+package httpd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"hash/crc32"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// batchID is the event id of the user's i-th event, each user's ids start with a hash of the username so no two users
+// share one
+func batchID(user string, i int) string {
+	return fmt.Sprintf("%08x-0000-4000-8000-%012d", crc32.ChecksumIEEE([]byte(user)), i)
+}
+
+func batchEvent(user string, i int) string {
+	return fmt.Sprintf(`{"username": %q, "unix_timestamp": %d, "event_uuid": %q, "ip_address": "68.193.88.%d"}`,
+		user, 1561600005+i*3600, batchID(user, i), 10+i)
+}
+
+func postBatch(t *testing.T, h *HTTPServer, body string) *model.BatchResponse {
+	w := serve(h, http.MethodPost, "/v1/events:batch", body)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	response := &model.BatchResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), response))
+	return response
+}
+
+func TestBatch_Array(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	// newest first, with a bad event in the middle, and bad events that aren't valid JSON at the end
+	events := []string{batchEvent("foo", 3), batchEvent("foo", 2), `{"username": "foo", "unix_timestamp": 1}`,
+		batchEvent("fooo", 0), batchEvent("foo", 1), batchEvent("foo", 0), `42`, `{"username": }`, `not json`,
+		`{"username": "a \" ] } , ["}`}
+	response := postBatch(t, h, "[\n"+strings.Join(events, ",\n")+"\n]")
+	require.Equal(t, 5, response.Processed)
+	require.Equal(t, 5, response.Failed)
+	require.Len(t, response.Results, 10)
+	for i, result := range response.Results {
+		require.Equal(t, i, result.Index)
+	}
+
+	require.Contains(t, response.Results[2].Error, "event_uuid")
+	require.Nil(t, response.Results[2].Verdict)
+	for _, i := range []int{6, 7, 8, 9} {
+		require.NotEmpty(t, response.Results[i].Error, i)
+		require.Nil(t, response.Results[i].Verdict, i)
+	}
+	require.Contains(t, response.Results[9].Error, "event_uuid")
+
+	// processed oldest first, so every verdict was judged against the event an hour before
+	for _, i := range []int{0, 1, 4} {
+		result := response.Results[i]
+		require.Empty(t, result.Error)
+		require.True(t, result.Verdict.Suspicious, result.EventID)
+		require.Equal(t, int64(3600), result.Verdict.PrecedingIPAccess.Travel.ElapsedSeconds, result.EventID)
+		require.Nil(t, result.Verdict.SubsequentIPAccess, result.EventID)
+	}
+	require.Equal(t, batchID("foo", 0), response.Results[5].EventID)
+	require.Nil(t, response.Results[5].Verdict.PrecedingIPAccess)
+	// a different user
+	require.Nil(t, response.Results[3].Verdict.PrecedingIPAccess)
+}
+
+func TestBatch_NDJSON(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	body := batchEvent("foo", 1) + "\n\n" + "not json\n" + batchEvent("foo", 0) + "\r\n" + batchEvent("bar", 0)
+	response := postBatch(t, h, body)
+	require.Equal(t, 3, response.Processed)
+	require.Equal(t, 1, response.Failed)
+	require.Len(t, response.Results, 4)
+	require.NotEmpty(t, response.Results[1].Error)
+	require.True(t, response.Results[0].Verdict.Suspicious)
+	require.False(t, response.Results[2].Verdict.Suspicious)
+
+	// events are idempotent, sending them again returns the same verdicts
+	again := postBatch(t, h, body)
+	require.Equal(t, response.Results[0].Verdict.Risk, again.Results[0].Verdict.Risk)
+	require.Equal(t, response.Results[0].Verdict.PrecedingIPAccess, again.Results[0].Verdict.PrecedingIPAccess)
+}
+
+func TestBatch_Invalid(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	for body, code := range map[string]int{
+		"":                                     http.StatusBadRequest,
+		" \n ":                                 http.StatusBadRequest,
+		"[]":                                   http.StatusBadRequest,
+		"[" + batchEvent("foo", 0) + ",":       http.StatusBadRequest,
+		"[" + batchEvent("foo", 0) + "} ":      http.StatusBadRequest,
+		`[{"username": [}]`:                    http.StatusBadRequest,
+		`["foo]`:                               http.StatusBadRequest,
+		strings.Repeat("{}\n", maxBatchSize+1): http.StatusRequestEntityTooLarge,
+		"[" + strings.Repeat("{},", maxBatchSize) + "{}]":             http.StatusRequestEntityTooLarge,
+		strings.Repeat("x", maxBatchLine+1):                           http.StatusBadRequest,
+		`[{"username": "` + strings.Repeat("x", maxBatchLine) + `"}]`: http.StatusBadRequest,
+		strings.Repeat(" ", maxBatchBody+1):                           http.StatusRequestEntityTooLarge,
+	} {
+		w := serve(h, http.MethodPost, "/v1/events:batch", body)
+		require.Equal(t, code, w.Code, w.Body.String())
+	}
+}
+
+func TestBatch_EventIDReused(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	response := postBatch(t, h, batchEvent("foo", 0))
+	require.Equal(t, 1, response.Processed)
+
+	// bar's event under one of foo's event ids fails on its own, without giving away foo's verdict. Users are detected
+	// concurrently, so foo's event is stored by a batch of its own first.
+	reused := strings.Replace(batchEvent("bar", 1), batchID("bar", 1), batchID("foo", 0), 1)
+	response = postBatch(t, h, batchEvent("bar", 0)+"\n"+reused)
+	require.Equal(t, 1, response.Processed)
+	require.Equal(t, 1, response.Failed)
+	require.Equal(t, detector.ErrEventConflict.Error(), response.Results[1].Error)
+	require.Nil(t, response.Results[1].Verdict)
+
+	record, err := storer.Event(context.Background(), batchID("foo", 0))
+	require.NoError(t, err)
+	require.Equal(t, "foo", record.UserName)
+}
@@ -2,6 +2,7 @@ package httpd
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/edwardsb/secureworks/model"
 	"github.com/edwardsb/secureworks/resources"
 	"github.com/go-chi/render"
@@ -48,12 +49,7 @@ func (e *EventRequestValidator) Middleware(next http.Handler) http.Handler {
 		}
 
 		// dealing with pointers to strings everywhere is kinda messy, so lets just do it once here
-		eventRequestValidated := &model.EventRequestValidated{
-			Username:      *eventRequest.Username,
-			UnixTimestamp: eventRequest.UnixTimestamp,
-			EventID:       *eventRequest.EventID,
-			IPAddress:     *eventRequest.IPAddress,
-		}
+		eventRequestValidated := eventRequest.Validated()
 
 		log.Printf("event request: %+v\n", eventRequestValidated)
 
@@ -65,6 +61,20 @@ func (e *EventRequestValidator) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+//Validate binds and validates a single event request that isn't the body of a request, like a record in a batch
+func (e *EventRequestValidator) Validate(payload []byte) (*model.EventRequestValidated, error) {
+	var eventRequest = &model.EventRequest{Schema: e.schema}
+	err := json.Unmarshal(payload, eventRequest)
+	if err != nil {
+		return nil, err
+	}
+	err = eventRequest.Bind(nil)
+	if err != nil {
+		return nil, err
+	}
+	return eventRequest.Validated(), nil
+}
+
 //EvenRequestFromContext is simply a convenience method for getting the validated request back out of context
 func EvenRequestFromContext(ctx context.Context) *model.EventRequestValidated {
 	if m, ok := ctx.Value(EventRequestKey).(*model.EventRequestValidated); ok {
@@ -0,0 +1,37 @@
+package httpd
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEventRequestValidator_IPAddress(t *testing.T) {
+	tests := []struct {
+		ip     string
+		status int
+	}{
+		{"68.193.88.103", http.StatusOK},
+		{"2001:db8::1", http.StatusOK},
+		{"::ffff:68.193.88.103", http.StatusOK},
+		{"68.193.88", http.StatusBadRequest},
+		{"not an ip", http.StatusBadRequest},
+	}
+
+	validated := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NotNil(t, EvenRequestFromContext(r.Context()))
+	})
+	handler := NewEvenRequestMiddleware().Middleware(validated)
+
+	for _, test := range tests {
+		body := fmt.Sprintf(`{"username": "foo", "unix_timestamp": 1561600005, "event_uuid": "05d86fca-825e-4515-86cc-7775a2d8047e", "ip_address": %q}`, test.ip)
+		req := httptest.NewRequest(http.MethodPost, "/v1/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, test.status, w.Code, test.ip)
+	}
+}
@@ -0,0 +1,124 @@
+package httpd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/edwardsb/secureworks/detector"
+	"github.com/edwardsb/secureworks/geoip"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/edwardsb/secureworks/store"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+)
+
+//cityGeoIP puts addresses ending in an even number in new york and the rest in los angeles
+type cityGeoIP struct{}
+
+func (c cityGeoIP) AnonymousIP(ip net.IP) (*geoip.AnonymousIP, error) {
+	return nil, geoip.ErrAnonymousIPUnavailable
+}
+
+func (c cityGeoIP) IsAnonymous(ip *geoip.AnonymousIP) bool {
+	return false
+}
+
+func (c cityGeoIP) Location(ip net.IP) (*geoip.Location, error) {
+	if ip.To4()[3]%2 == 0 {
+		return &geoip.Location{Latitude: 40.7128, Longitude: -74.0060, AccuracyRadius: 5}, nil
+	}
+	return &geoip.Location{Latitude: 34.0522, Longitude: -118.2437, AccuracyRadius: 5}, nil
+}
+
+func (c cityGeoIP) ASN(ip net.IP) (*geoip.ASN, error) {
+	return nil, geoip.ErrASNUnavailable
+}
+
+func TestServer_ConcurrentEventsForOneUser(t *testing.T) {
+	// a file rather than :memory:, so the requests really do run on connections of their own
+	dir, err := ioutil.TempDir("", "secureworks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db, err := sql.Open("sqlite3", filepath.Join(dir, "events.db")+"?_busy_timeout=10000&_journal_mode=WAL")
+	require.NoError(t, err)
+	storer := store.NewSqliteDb(db)
+	require.NoError(t, storer.Open())
+	defer storer.Close()
+	h := NewHTTPServer(detector.NewDetector(storer, cityGeoIP{}, detector.NewImpossibleTravel(detector.DefaultFeasibility, 0)), storer)
+	h.initRouter()
+
+	// an hour apart, alternating between new york and los angeles, so every leg is impossible
+	const events = 30
+	var ids []string
+	for i := 0; i < events; i++ {
+		ids = append(ids, fmt.Sprintf("00000000-0000-4000-8000-%012d", i))
+	}
+	order := rand.New(rand.NewSource(1)).Perm(events)
+
+	// require can't stop the test from another goroutine, so the responses are checked once they are all in
+	var wg sync.WaitGroup
+	failures := make(chan string, events)
+	for _, i := range order {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := fmt.Sprintf(`{"username": "foo", "unix_timestamp": %d, "event_uuid": %q, "ip_address": "68.193.88.%d"}`,
+				1561600005+i*3600, ids[i], 10+i)
+			w := serve(h, http.MethodPost, "/v1/", body)
+			if w.Code != http.StatusOK {
+				failures <- fmt.Sprintf("%s: %d %s", ids[i], w.Code, w.Body.String())
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(failures)
+	for failure := range failures {
+		t.Error(failure)
+	}
+	require.False(t, t.Failed())
+
+	// whatever order they arrived in, every stored verdict was judged against the event's actual predecessor
+	sort.Strings(ids)
+	for i, id := range ids {
+		record, err := storer.Event(context.Background(), id)
+		require.NoError(t, err)
+		verdict := &detector.Verdict{}
+		require.NoError(t, json.Unmarshal(record.Verdict, verdict))
+		if i == 0 {
+			require.Nil(t, verdict.Preceding, id)
+			continue
+		}
+		require.NotNil(t, verdict.Preceding, id)
+		require.Equal(t, ids[i-1], verdict.Preceding.Access.EventID, id)
+		require.True(t, verdict.Preceding.Suspicious, id)
+		require.Equal(t, model.LocationGeoIP, verdict.Preceding.Access.Source, id)
+	}
+}
+
+func TestServer_EventIDReused(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	event := `{"username": %q, "unix_timestamp": 1561600005, "event_uuid": "05d86fca-825e-4515-86cc-7775a2d8047e", "ip_address": "68.193.88.10"}`
+	w := serve(h, http.MethodPost, "/v1/", fmt.Sprintf(event, "alice"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serve(h, http.MethodPost, "/v1/", fmt.Sprintf(event, "alice"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// another user's login under alice's event id gets neither her verdict nor stored
+	w = serve(h, http.MethodPost, "/v1/", fmt.Sprintf(event, "mallory"))
+	require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	require.NotContains(t, w.Body.String(), "40.7128")
+	record, err := storer.Event(context.Background(), "05d86fca-825e-4515-86cc-7775a2d8047e")
+	require.NoError(t, err)
+	require.Equal(t, "alice", record.UserName)
+}
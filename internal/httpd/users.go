@@ -0,0 +1,85 @@
+package httpd
+
+import (
+	"errors"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//errInvalidCursor is returned for a cursor that wasn't handed out by a paged endpoint
+var errInvalidCursor = errors.New("invalid cursor")
+
+//usersRouter has the read endpoints for a user's logins
+func (h *HTTPServer) usersRouter() chi.Router {
+	r := chi.NewRouter()
+	r.Get("/{username}/events", h.timeline)
+	return r
+}
+
+//timeline lists the user's logins oldest first, each with the distance, speed and verdict of the trip from the login
+//before it. They can be filtered by time with ?from= and ?to= as unix timestamps.
+func (h *HTTPServer) timeline(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+	filter, err := eventFilter(r)
+	if err != nil {
+		renderStatus(w, r, http.StatusBadRequest, err)
+		return
+	}
+	limit := filter.Limit
+	// ask for one more than the page holds, to know whether there is another page
+	filter.Limit++
+	events, err := h.detector.Timeline(r.Context(), username, filter)
+	if err != nil {
+		renderError(w, r, err)
+		return
+	}
+
+	timeline := &model.Timeline{UserName: username, Events: events}
+	if len(events) > limit {
+		timeline.Events = events[:limit]
+		last := timeline.Events[limit-1]
+		timeline.NextCursor = cursor(last.Timestamp, last.EventID)
+	}
+	render.Render(w, r, timeline)
+}
+
+func eventFilter(r *http.Request) (model.EventFilter, error) {
+	query := r.URL.Query()
+	filter := model.EventFilter{}
+	var err error
+	if filter.From, err = optionalInt(query.Get("from"), "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = optionalInt(query.Get("to"), "to"); err != nil {
+		return filter, err
+	}
+	if value := query.Get("cursor"); len(value) > 0 {
+		filter.After, err = parseCursor(value)
+		if err != nil {
+			return filter, err
+		}
+	}
+	filter.Limit, err = pageLimit(query.Get("limit"))
+	return filter, err
+}
+
+//cursor is the position of the last event on a page, as timestamp:event id
+func cursor(timestamp int64, eventID string) string {
+	return strconv.FormatInt(timestamp, 10) + ":" + eventID
+}
+
+func parseCursor(value string) (*model.EventPosition, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return nil, errInvalidCursor
+	}
+	timestamp, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	return &model.EventPosition{Timestamp: timestamp, EventID: parts[1]}, nil
+}
@@ -0,0 +1,71 @@
+package httpd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestUsers_Timeline(t *testing.T) {
+	h, storer := newAlertTestServer(t)
+	defer storer.Close()
+
+	// new york and los angeles, two hours apart, except the last two which are a day apart
+	for i, offset := range []int{0, 7200, 14400, 21600, 108000} {
+		body := fmt.Sprintf(`{"username": "foo", "unix_timestamp": %d, "event_uuid": "00000000-0000-4000-8000-%012d", "ip_address": "68.193.88.%d"}`,
+			1561600005+offset, i, 10+i)
+		w := serve(h, http.MethodPost, "/v1/", body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+	timeline := func(target string) *model.Timeline {
+		w := serve(h, http.MethodGet, target, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		timeline := &model.Timeline{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), timeline))
+		return timeline
+	}
+
+	page := timeline("/v1/users/foo/events")
+	require.Equal(t, "foo", page.UserName)
+	require.Len(t, page.Events, 5)
+	require.Empty(t, page.NextCursor)
+	require.Nil(t, page.Events[0].Previous)
+	require.Equal(t, "68.193.88.10", page.Events[0].IP)
+	for i, event := range page.Events[1:] {
+		require.Equal(t, page.Events[i].EventID, event.Previous.EventID)
+		require.InDelta(t, 3935, event.Previous.DistanceKm, 10)
+		require.Equal(t, i < 3, event.Previous.Suspicious, event.EventID)
+		require.NotNil(t, event.Travel)
+	}
+
+	// a page at a time, each page's first login still has the trip that led to it
+	var seen []*model.TimelineEvent
+	target := "/v1/users/foo/events?limit=2"
+	for {
+		page := timeline(target)
+		seen = append(seen, page.Events...)
+		if page.NextCursor == "" {
+			break
+		}
+		target = "/v1/users/foo/events?limit=2&cursor=" + page.NextCursor
+	}
+	require.Len(t, seen, 5)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", seen[2].Previous.EventID)
+
+	page = timeline("/v1/users/foo/events?from=1561607205&to=1561621605")
+	require.Len(t, page.Events, 2)
+	require.Equal(t, "00000000-0000-4000-8000-000000000001", page.Events[0].EventID)
+	require.NotNil(t, page.Events[0].Previous)
+
+	page = timeline("/v1/users/nobody/events")
+	require.Empty(t, page.Events)
+	require.NotNil(t, page.Events)
+
+	for _, query := range []string{"?from=x", "?to=x", "?cursor=x", "?cursor=x:e", "?cursor=1:", "?limit=0", "?limit=501"} {
+		w := serve(h, http.MethodGet, "/v1/users/foo/events"+query, "")
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
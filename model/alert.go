@@ -0,0 +1,183 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+//The statuses of an alert. Alerts start open, analysts move them on as they work them.
+const (
+	//AlertOpen is the status of an alert that hasn't been looked at yet
+	AlertOpen = "open"
+	//AlertAcknowledged alerts are being looked at
+	AlertAcknowledged = "acknowledged"
+	//AlertResolved alerts were real and have been dealt with
+	AlertResolved = "resolved"
+	//AlertFalsePositive alerts weren't suspicious after all, the detector stops alerting on travel between the pair of
+	//locations of each of the alert's legs for the user
+	AlertFalsePositive = "false_positive"
+)
+
+//AlertActorDetector is the actor of the changes the detector makes to an alert when its event is evaluated again
+const AlertActorDetector = "detector"
+
+//AlertStatuses are the statuses an alert can have
+var AlertStatuses = []string{AlertOpen, AlertAcknowledged, AlertResolved, AlertFalsePositive}
+
+//The severities of an alert, from its risk score
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+//Severities are the severities from lowest to highest
+var Severities = []string{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+//Severity buckets a 0 to 100 risk score
+func Severity(score int) string {
+	switch {
+	case score >= 90:
+		return SeverityCritical
+	case score >= 70:
+		return SeverityHigh
+	case score >= 40:
+		return SeverityMedium
+	}
+	return SeverityLow
+}
+
+//Alert records that an event was found suspicious. It references the event and the neighbours it was judged
+//against, with the score and reasons of the event's latest suspicious verdict. Its status and assignee are worked by
+//analysts, and by the detector when the event is evaluated again. History is every change made to the alert, oldest
+//first. CreatedAt and UpdatedAt are unix timestamps.
+type Alert struct {
+	ID                int64   `db:"id" json:"id"`
+	EventID           string  `db:"event_uuid" json:"eventId"`
+	UserName          string  `db:"username" json:"username"`
+	EventTimestamp    int64   `db:"event_timestamp" json:"eventTimestamp"`
+	PrecedingEventID  string  `db:"preceding_event_uuid" json:"precedingEventId,omitempty"`
+	SubsequentEventID string  `db:"subsequent_event_uuid" json:"subsequentEventId,omitempty"`
+	Score             int     `db:"score" json:"score"`
+	Severity          string  `db:"severity" json:"severity"`
+	Status            string  `db:"status" json:"status"`
+	Assignee          string  `db:"assignee" json:"assignee,omitempty"`
+	Reasons           Reasons `db:"reasons" json:"reasons"`
+	CreatedAt         int64   `db:"created_at" json:"createdAt"`
+	UpdatedAt         int64   `db:"updated_at" json:"updatedAt,omitempty"`
+
+	History []*AlertChange `db:"-" json:"history,omitempty"`
+}
+
+//Render satisfies the Renderer interface in Chi
+func (a *Alert) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+//Reasons are stored as a JSON array
+type Reasons []Reason
+
+//Value serializes the reasons for the database
+func (r Reasons) Value() (driver.Value, error) {
+	if r == nil {
+		r = Reasons{}
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+//Scan deserializes the reasons from the database
+func (r *Reasons) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	case nil:
+		*r = nil
+		return nil
+	}
+	return errors.Errorf("cannot scan %T into reasons", src)
+}
+
+//AlertFilter selects alerts. Empty fields don't filter, From and To are an inclusive and exclusive range of event
+//timestamps when set. Alerts come newest first, Before is the cursor, only alerts with a lower id are returned.
+type AlertFilter struct {
+	UserName   string
+	From       *int64
+	To         *int64
+	Severities []string
+	Statuses   []string
+	Before     int64
+	Limit      int
+}
+
+//AlertUpdate is a change an analyst makes to an alert. Status and Assignee are only changed when they are set, an
+//empty Assignee unassigns the alert. Actor is who made the change and is required, Comment is optional.
+type AlertUpdate struct {
+	Status   *string `json:"status"`
+	Assignee *string `json:"assignee"`
+	Comment  string  `json:"comment"`
+	Actor    string  `json:"actor"`
+}
+
+//Bind validates the update
+func (u *AlertUpdate) Bind(r *http.Request) error {
+	if len(u.Actor) == 0 {
+		return errors.New("actor is required")
+	}
+	if u.Status == nil && u.Assignee == nil && len(u.Comment) == 0 {
+		return errors.New("nothing to update, set status, assignee or comment")
+	}
+	if u.Status == nil {
+		return nil
+	}
+	for _, status := range AlertStatuses {
+		if *u.Status == status {
+			return nil
+		}
+	}
+	return errors.Errorf("invalid status %q", *u.Status)
+}
+
+//AlertChange is an entry in an alert's history, one is recorded for every update and they are never changed.
+//It keeps the status and assignee from before and after the update, a comment on its own leaves them the same.
+type AlertChange struct {
+	ID               int64  `db:"id" json:"id"`
+	AlertID          int64  `db:"alert_id" json:"alertId"`
+	Actor            string `db:"actor" json:"actor"`
+	PreviousStatus   string `db:"previous_status" json:"previousStatus"`
+	Status           string `db:"status" json:"status"`
+	PreviousAssignee string `db:"previous_assignee" json:"previousAssignee,omitempty"`
+	Assignee         string `db:"assignee" json:"assignee,omitempty"`
+	Comment          string `db:"comment" json:"comment,omitempty"`
+	CreatedAt        int64  `db:"created_at" json:"createdAt"`
+}
+
+//MinSuppressionRadiusKm is the smallest area around either end of a false positive's leg that is suppressed, so the
+//next login from the same city isn't alerted on because geoip placed it a few km away
+const MinSuppressionRadiusKm = 50
+
+//Suppression stops the detector alerting on travel between a pair of locations for a user. They are made when an
+//alert is marked a false positive, one for each leg of travel it was raised for, from the location of the alert's
+//event to the location of the neighbour on that leg. They are removed if the alert is moved on from that. A leg is
+//only suppressed when one of its ends is inside the first circle and the other inside the second.
+type Suppression struct {
+	ID              int64   `db:"id" json:"id"`
+	AlertID         int64   `db:"alert_id" json:"alertId"`
+	Leg             string  `db:"leg" json:"leg"`
+	UserName        string  `db:"username" json:"username"`
+	Lat             float64 `db:"lat" json:"lat"`
+	Lon             float64 `db:"lon" json:"lon"`
+	Radius          uint16  `db:"radius" json:"radius"`
+	NeighbourLat    float64 `db:"neighbour_lat" json:"neighbourLat"`
+	NeighbourLon    float64 `db:"neighbour_lon" json:"neighbourLon"`
+	NeighbourRadius uint16  `db:"neighbour_radius" json:"neighbourRadius"`
+	CreatedAt       int64   `db:"created_at" json:"createdAt"`
+}
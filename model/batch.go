@@ -0,0 +1,26 @@
+package model
+
+import (
+	"net/http"
+)
+
+//BatchResult is the outcome of one event in a batch. Index is the event's position in the batch, counting from 0.
+//Error is set instead of Verdict when the event was rejected or couldn't be processed.
+type BatchResult struct {
+	Index   int            `json:"index"`
+	EventID string         `json:"eventId,omitempty"`
+	Verdict *EventResponse `json:"verdict,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+//BatchResponse is the JSON response to a batch, with a result for every event in the order they were sent
+type BatchResponse struct {
+	Processed int            `json:"processed"`
+	Failed    int            `json:"failed"`
+	Results   []*BatchResult `json:"results"`
+}
+
+//Render satisfies the Renderer interface in Chi
+func (b *BatchResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -46,29 +46,122 @@ func (e *EventRequest) Bind(r *http.Request) error {
 	return nil
 }
 
-//Geo holds location information
+//Validated is the request once it has been validated, with the pointers taken care of
+func (e *EventRequest) Validated() *EventRequestValidated {
+	return &EventRequestValidated{
+		Username:      *e.Username,
+		UnixTimestamp: e.UnixTimestamp,
+		EventID:       *e.EventID,
+		IPAddress:     *e.IPAddress,
+	}
+}
+
+//LocationSource values say where a Geo came from
+const (
+	LocationGeoIP          = "geoip"
+	LocationUnknown        = "unknown"
+	LocationTrustedNetwork = "trusted_network"
+)
+
+//Geo holds location information. When Source is LocationUnknown the coordinates are meaningless.
+//Label names the trusted network the location came from. Precision is the geoip precision level, Country the iso
+//country code and TimeZone the IANA time zone, they are empty for other sources and for events stored before they
+//were recorded.
 type Geo struct {
-	Lat    float64 `db:"lat" json:"lat"`
-	Lon    float64 `db:"lon" json:"lon"`
-	Radius uint16  `db:"radius" json:"radius"`
+	Lat       float64 `db:"lat" json:"lat"`
+	Lon       float64 `db:"lon" json:"lon"`
+	Radius    uint16  `db:"radius" json:"radius"`
+	Source    string  `db:"location_source" json:"source,omitempty"`
+	Label     string  `db:"location_label" json:"label,omitempty"`
+	Precision string  `db:"location_precision" json:"precision,omitempty"`
+	Country   string  `db:"country" json:"country,omitempty"`
+	TimeZone  string  `db:"time_zone" json:"timeZone,omitempty"`
+}
+
+//Known is false when the location couldn't be determined. Events stored before the source was recorded were
+//all geolocated, so an empty source counts as known.
+func (g Geo) Known() bool {
+	return g.Source != LocationUnknown
+}
+
+//Network is the autonomous system an address was announced from. ASN is 0 when it couldn't be looked up.
+type Network struct {
+	ASN          uint   `db:"asn" json:"asn"`
+	Organization string `db:"as_org" json:"organization"`
+}
+
+//Travel is a trip between two accesses measured against the travel model. Distances are in km and speeds in km/h.
+//MinDistanceKm is the distance between the accuracy circles of the two accesses, the required speed and the limit
+//are based on it. LimitKmh is the fastest average speed allowed, for air travel that includes the airport overhead.
+//Confidence, from 0 to 1, is how much of the distance is left once the accuracy radii are taken into account.
+type Travel struct {
+	CurrentRadiusKm  uint16  `json:"currentRadiusKm"`
+	AccessRadiusKm   uint16  `json:"accessRadiusKm"`
+	DistanceKm       float64 `json:"distanceKm"`
+	MinDistanceKm    float64 `json:"minDistanceKm"`
+	ElapsedSeconds   int64   `json:"elapsedSeconds"`
+	RequiredSpeedKmh float64 `json:"requiredSpeedKmh"`
+	LimitKmh         float64 `json:"limitKmh"`
+	Mode             string  `json:"mode"`
+	Confidence       float64 `json:"confidence"`
 }
 
-//IPAccess holds geolocation information and other data about access events
+//IPAccess holds geolocation information and other data about access events. Speed is in miles per hour and only
+//kept for older clients, Travel has the full picture in metric.
 type IPAccess struct {
 	Geo
-	Speed     float64 `json:"speed"`
-	IP        string  `json:"ip"`
-	Timestamp int64   `json:"timestamp"`
+	Network   *Network `json:"network,omitempty"`
+	Travel    *Travel  `json:"travel,omitempty"`
+	Speed     float64  `json:"speed"`
+	IP        string   `json:"ip"`
+	IPClass   string   `json:"ipClass,omitempty"`
+	Timestamp int64    `json:"timestamp"`
 }
 
 //EventResponse is used as the JSON response to the web request. Using pointer to bool since the field is optional
 // and should only be included, when there is a corresponding preceding/subsequent access
 type EventResponse struct {
-	Current                        Geo       `json:"currentGeo"`
-	TravelToCurrentGeoSuspicious   *bool     `json:"travelToCurrentGeoSuspicious,omitempty"`
-	TravelFromCurrentGeoSuspicious *bool     `json:"travelFromCurrentGeoSuspicious,omitempty"`
-	PrecedingIPAccess              *IPAccess `json:"precedingIpAccess,omitempty"`
-	SubsequentIPAccess             *IPAccess `json:"subsequentIpAccess,omitempty"`
+	Suspicious                     bool              `json:"suspicious"`
+	NetworkList                    *NetworkListEntry `json:"networkList,omitempty"`
+	Current                        Geo               `json:"currentGeo"`
+	CurrentNetwork                 *Network          `json:"currentNetwork,omitempty"`
+	CurrentIPClass                 string            `json:"currentIpClass,omitempty"`
+	Anonymity                      *Anonymity        `json:"anonymity,omitempty"`
+	TravelToCurrentGeoSuspicious   *bool             `json:"travelToCurrentGeoSuspicious,omitempty"`
+	TravelFromCurrentGeoSuspicious *bool             `json:"travelFromCurrentGeoSuspicious,omitempty"`
+	PrecedingIPAccess              *IPAccess         `json:"precedingIpAccess,omitempty"`
+	SubsequentIPAccess             *IPAccess         `json:"subsequentIpAccess,omitempty"`
+	Risk                           *Risk             `json:"risk,omitempty"`
+	Reasons                        []Reason          `json:"reasons"`
+}
+
+//Reason explains the outcome of one rule, for the event as a whole or for one leg of travel. Code is a stable,
+//machine readable outcome and Message is for people. Travel has the numbers travel rules worked from, the
+//distances, time, required speed, the limit it was held to and the accuracy radii of both ends.
+type Reason struct {
+	Code       string  `json:"code"`
+	Rule       string  `json:"rule"`
+	Leg        string  `json:"leg,omitempty"`
+	Triggered  bool    `json:"triggered"`
+	Suppressed bool    `json:"suppressed,omitempty"`
+	Message    string  `json:"message,omitempty"`
+	Travel     *Travel `json:"travel,omitempty"`
+}
+
+//Risk is a single 0 to 100 score for the event, with the signals that went into it
+type Risk struct {
+	Score   int      `json:"score"`
+	Signals []Signal `json:"signals"`
+}
+
+//Signal is one input to the risk score. Points is the signal's weight when it fired, and 0 when it didn't.
+//Detail explains the outcome, including why a signal couldn't be evaluated.
+type Signal struct {
+	Name   string `json:"name"`
+	Fired  bool   `json:"fired"`
+	Weight int    `json:"weight"`
+	Points int    `json:"points"`
+	Detail string `json:"detail,omitempty"`
 }
 
 //Render satisfies the Renderer interface in Chi
@@ -76,26 +169,42 @@ func (e *EventResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
-//Record is what we are storing in the database
+//Anonymity is the anonymous ip classification of an address. Checked is false when no anonymous ip
+//database was configured at the time, in which case the flags don't mean anything.
+type Anonymity struct {
+	Checked     bool `db:"anonymity_checked" json:"-"`
+	Anonymous   bool `db:"anonymous" json:"anonymous"`
+	VPN         bool `db:"anonymous_vpn" json:"vpn"`
+	Hosting     bool `db:"hosting_provider" json:"hostingProvider"`
+	PublicProxy bool `db:"public_proxy" json:"publicProxy"`
+	TorExitNode bool `db:"tor_exit_node" json:"torExitNode"`
+}
+
+//Record is what we are storing in the database. Records are append only, EventID is the idempotency key.
+//Verdict holds the serialized verdict computed when the event was first seen, so a retried event can get
+//the same answer back.
 type Record struct {
-	ID        int64  `db:"id"`
+	ID        int64  `db:"id" json:"id"`
+	EventID   string `db:"event_uuid" dynamo:"event_uuid" json:"event_uuid"`
 	UserName  string `db:"username" dynamo:"username" json:"username"`
 	Timestamp int64  `db:"timestamp" dynamo:"ts" json:"timestamp"`
 	IP        string `db:"ip" json:"ip"`
-	Anonymous bool   `db:"anonymous" json:"anonymous"`
+	IPClass   string `db:"ip_class" json:"ip_class"`
+	Anonymity
+	Network
 	Geo
+	Verdict []byte `db:"verdict" json:"-"`
 }
 
-//NewRecord creates a new record, adding the anonymous field
-func NewRecord(userName string, timestamp int64, ip string, anonymous bool, lat float64, lon float64, radius uint16) *Record {
+//NewRecord creates a new record, adding the anonymous ip classification, network and location
+func NewRecord(userName string, eventID string, timestamp int64, ip string, anonymity Anonymity, network Network, geo Geo) *Record {
 	return &Record{
+		EventID:   eventID,
 		UserName:  userName,
 		Timestamp: timestamp,
 		IP:        ip,
-		Anonymous: anonymous,
-		Geo: Geo{
-			Lat:    lat,
-			Lon:    lon,
-			Radius: radius,
-		}}
+		Anonymity: anonymity,
+		Network:   network,
+		Geo:       geo,
+	}
 }
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"github.com/pkg/errors"
+	"net"
+	"net/http"
+)
+
+//The lists a NetworkListEntry can be on
+const (
+	AllowList = "allow"
+	DenyList  = "deny"
+)
+
+//NetworkListEntry is a CIDR range on the allow or deny list. Entries without a username are global and apply to
+//every user. Matches on the allow list are never alerted on, matches on the deny list always are.
+type NetworkListEntry struct {
+	ID        int64  `db:"id" json:"id"`
+	List      string `db:"list" json:"list"`
+	CIDR      string `db:"cidr" json:"cidr"`
+	UserName  string `db:"username" json:"username,omitempty"`
+	Comment   string `db:"comment" json:"comment,omitempty"`
+	CreatedAt int64  `db:"created_at" json:"createdAt"`
+
+	network *net.IPNet
+}
+
+//Bind validates the entry and puts the CIDR in canonical form, so 10.1.2.3/8 is stored as 10.0.0.0/8
+func (e *NetworkListEntry) Bind(r *http.Request) error {
+	if e.List != AllowList && e.List != DenyList {
+		return errors.Errorf("list must be %q or %q", AllowList, DenyList)
+	}
+	network, err := e.Network()
+	if err != nil {
+		return err
+	}
+	e.CIDR = network.String()
+	return nil
+}
+
+//Render satisfies the Renderer interface in Chi
+func (e *NetworkListEntry) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
+//Network parses the CIDR of the entry
+func (e *NetworkListEntry) Network() (*net.IPNet, error) {
+	if e.network == nil {
+		_, network, err := net.ParseCIDR(e.CIDR)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cidr %q", e.CIDR)
+		}
+		e.network = network
+	}
+	return e.network, nil
+}
+
+//Global is true when the entry applies to every user
+func (e *NetworkListEntry) Global() bool {
+	return len(e.UserName) == 0
+}
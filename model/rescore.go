@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+//MaxRescoreUsers is the most users a rescore request can name
+const MaxRescoreUsers = 100
+
+//RescoreRequest says which users to rescore and whether to write the new verdicts back to the store
+type RescoreRequest struct {
+	Users []string `json:"users"`
+	Write bool     `json:"write"`
+}
+
+//Bind validates the request. A request is rescored while it waits, taking each user's lock in turn, so it has to name
+//its users, at most MaxRescoreUsers of them. Walking every user is left to the rescore command.
+func (q *RescoreRequest) Bind(r *http.Request) error {
+	if len(q.Users) == 0 {
+		return errors.New("users are required, rescore every user with the rescore command")
+	}
+	if len(q.Users) > MaxRescoreUsers {
+		return fmt.Errorf("at most %d users can be rescored at a time", MaxRescoreUsers)
+	}
+	return nil
+}
+
+//RescoreChange is an event that would be alerted on, or would no longer be, with the current configuration.
+//Reasons are the reasons that triggered, from the new verdict for an added alert and the stored one for a removed one.
+type RescoreChange struct {
+	EventID       string   `json:"eventId"`
+	UserName      string   `json:"username"`
+	Timestamp     int64    `json:"timestamp"`
+	PreviousScore int      `json:"previousScore"`
+	Score         int      `json:"score"`
+	Reasons       []Reason `json:"reasons"`
+}
+
+//RescoreReport sums up a rescore. Changed counts the events whose verdict differs in whether it is suspicious, its
+//risk score or the rules that triggered, Added and Removed are the ones that became or stopped being suspicious.
+//Written is true when the new verdicts were saved, which raised the alerts of the added and resolved the open and
+//acknowledged alerts of the removed.
+type RescoreReport struct {
+	Users   int              `json:"users"`
+	Events  int              `json:"events"`
+	Changed int              `json:"changed"`
+	Written bool             `json:"written"`
+	Added   []*RescoreChange `json:"added"`
+	Removed []*RescoreChange `json:"removed"`
+}
+
+//Render satisfies the Renderer interface in Chi
+func (p *RescoreReport) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"net/http"
+)
+
+//EventPosition is a place in a user's events, which are ordered by (timestamp, event id)
+type EventPosition struct {
+	Timestamp int64
+	EventID   string
+}
+
+//EventFilter selects a user's events, in (timestamp, event id) order. Empty fields don't filter, From and To are an
+//inclusive and exclusive range of timestamps when set. After is the cursor, only events after it are returned.
+type EventFilter struct {
+	From  *int64
+	To    *int64
+	After *EventPosition
+	Limit int
+}
+
+//TimelineEvent is a login on a user's timeline. Travel is the impossible travel assessment of the trip from the
+//login before it, and Speed that trip's speed in miles per hour, like in the event response.
+type TimelineEvent struct {
+	EventID string `json:"eventId"`
+	IPAccess
+	// Previous is the trip from the login before, nil for the user's first login
+	Previous *TimelineLeg `json:"previous,omitempty"`
+}
+
+//TimelineLeg is the trip between two consecutive logins. Distance is in km and speed in km/h.
+type TimelineLeg struct {
+	EventID         string  `json:"eventId"`
+	DistanceKm      float64 `json:"distanceKm"`
+	ElapsedSeconds  int64   `json:"elapsedSeconds"`
+	SpeedKmh        float64 `json:"speedKmh"`
+	Suspicious      bool    `json:"suspicious"`
+	LocationUnknown bool    `json:"locationUnknown,omitempty"`
+}
+
+//Timeline is a page of a user's logins, NextCursor is passed as ?cursor= to get the next one and is empty on the
+//last page
+type Timeline struct {
+	UserName   string           `json:"username"`
+	Events     []*TimelineEvent `json:"events"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+}
+
+//Render satisfies the Renderer interface in Chi
+func (t *Timeline) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
@@ -0,0 +1,21 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"log"
+)
+
+//Log writes notifications to the standard logger as JSON
+type Log struct{}
+
+//Notify logs the notification
+func (l Log) Notify(ctx context.Context, notification *Notification) error {
+	b, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize notification")
+	}
+	log.Printf("%s %s\n", notification.Type, b)
+	return nil
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/hashicorp/go-multierror"
+)
+
+//VerdictChanged is sent when re-evaluating a stored event, because another event arrived out of order and became
+//its new neighbour, changes its verdict
+const VerdictChanged = "verdict_changed"
+
+//Notification is what the detector tells the configured alert outputs. CausedBy is the event id that led to it.
+//Previous is the verdict the event had before, it is nil when the event had no stored verdict.
+type Notification struct {
+	Type     string               `json:"type"`
+	UserName string               `json:"username"`
+	EventID  string               `json:"eventId"`
+	CausedBy string               `json:"causedBy,omitempty"`
+	Previous *model.EventResponse `json:"previous,omitempty"`
+	Current  *model.EventResponse `json:"current"`
+}
+
+//Notifier is an alert output
+type Notifier interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+//Notifiers sends every notification to all of its outputs. An output failing doesn't stop the rest from
+//being notified, all of the errors are returned together.
+type Notifiers []Notifier
+
+//Notify sends the notification to every output
+func (n Notifiers) Notify(ctx context.Context, notification *Notification) error {
+	var result error
+	for _, notifier := range n {
+		err := notifier.Notify(ctx, notification)
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result
+}
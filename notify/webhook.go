@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+)
+
+//Webhook posts notifications as JSON to a url, anything other than a 2xx response is an error
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+//NewWebhook creates a webhook output for the url
+func NewWebhook(url string, timeout time.Duration) *Webhook {
+	return &Webhook{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+//Notify posts the notification
+func (w *Webhook) Notify(ctx context.Context, notification *Notification) error {
+	b, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize notification")
+	}
+	request, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "failed to create webhook request")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := w.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "failed to call webhook")
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.Errorf("webhook returned %s", response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhook_Notify(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notification := &Notification{Type: VerdictChanged, UserName: "foo", EventID: "e-200", CausedBy: "e-150",
+		Current: &model.EventResponse{Suspicious: true}}
+	err := NewWebhook(server.URL, time.Second).Notify(context.Background(), notification)
+	require.NoError(t, err)
+	require.Equal(t, "e-200", received.EventID)
+	require.True(t, received.Current.Suspicious)
+}
+
+func TestWebhook_NotifyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := Notifiers{Log{}, NewWebhook(server.URL, time.Second)}.Notify(context.Background(), &Notification{Type: VerdictChanged})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "502")
+}
@@ -0,0 +1,22 @@
+-- events are append only, every login is kept and event_uuid makes inserts idempotent.
+-- event_uuid is collated "C" so ties on timestamp are broken bytewise, the same as the other backends,
+-- rather than by whatever locale the database was created with.
+create table if not exists login_events
+(
+	id bigserial
+		constraint login_events_pk
+			primary key,
+	event_uuid text collate "C" not null
+		constraint login_events_event_uuid
+			unique,
+	username text not null,
+	timestamp bigint not null,
+	lat double precision,
+	lon double precision,
+	radius integer,
+	ip text,
+	anonymous boolean,
+	verdict bytea
+);
+create index if not exists login_events_username_timestamp
+	on login_events (username, timestamp, event_uuid);
@@ -0,0 +1,7 @@
+alter table login_events alter column anonymous drop not null;
+alter table login_events alter column anonymous drop default;
+alter table login_events drop column tor_exit_node;
+alter table login_events drop column public_proxy;
+alter table login_events drop column hosting_provider;
+alter table login_events drop column anonymous_vpn;
+alter table login_events drop column anonymity_checked;
@@ -0,0 +1,9 @@
+-- the full anonymous ip classification, anonymity_checked is false for events seen without an anonymous ip database.
+alter table login_events add column anonymity_checked boolean not null default false;
+alter table login_events add column anonymous_vpn boolean not null default false;
+alter table login_events add column hosting_provider boolean not null default false;
+alter table login_events add column public_proxy boolean not null default false;
+alter table login_events add column tor_exit_node boolean not null default false;
+update login_events set anonymous = false where anonymous is null;
+alter table login_events alter column anonymous set default false;
+alter table login_events alter column anonymous set not null;
@@ -0,0 +1,2 @@
+alter table login_events drop column as_org;
+alter table login_events drop column asn;
@@ -0,0 +1,2 @@
+alter table login_events drop column location_source;
+alter table login_events drop column ip_class;
@@ -0,0 +1 @@
+alter table login_events drop column location_label;
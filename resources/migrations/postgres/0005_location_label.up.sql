@@ -0,0 +1,2 @@
+-- the trusted network an event was located by, empty when the location came from geoip.
+alter table login_events add column location_label text not null default '';
@@ -0,0 +1,2 @@
+drop index if exists network_lists_username;
+drop table if exists network_lists;
@@ -0,0 +1,16 @@
+-- cidr allow and deny lists, an empty username makes the entry global.
+create table network_lists
+(
+	id bigserial
+		constraint network_lists_pk
+			primary key,
+	list text not null
+		constraint network_lists_list
+			check (list in ('allow', 'deny')),
+	cidr text not null,
+	username text not null default '',
+	comment text not null default '',
+	created_at bigint not null
+);
+create index network_lists_username
+	on network_lists (username);
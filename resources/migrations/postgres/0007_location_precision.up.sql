@@ -0,0 +1,2 @@
+-- how precise the geoip location is, city, region or centroid. empty for other sources and older events.
+alter table login_events add column location_precision text not null default '';
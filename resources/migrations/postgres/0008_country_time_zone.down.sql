@@ -0,0 +1,2 @@
+alter table login_events drop column time_zone;
+alter table login_events drop column country;
@@ -0,0 +1,2 @@
+drop index if exists alerts_username_event_timestamp;
+drop table if exists alerts;
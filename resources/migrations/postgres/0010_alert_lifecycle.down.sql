@@ -0,0 +1,6 @@
+drop index if exists suppressions_username;
+drop table if exists suppressions;
+drop index if exists alert_history_alert_id;
+drop table if exists alert_history;
+alter table alerts drop column updated_at;
+alter table alerts drop column assignee;
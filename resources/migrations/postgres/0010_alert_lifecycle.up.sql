@@ -0,0 +1,40 @@
+-- alerts are worked by analysts, every change to one is kept in alert_history, which is only ever inserted into.
+alter table alerts add column assignee text not null default '';
+alter table alerts add column updated_at bigint not null default 0;
+create table alert_history
+(
+	id bigserial
+		constraint alert_history_pk
+			primary key,
+	alert_id bigint not null
+		constraint alert_history_alert_id
+			references alerts (id),
+	actor text not null,
+	previous_status text not null,
+	status text not null,
+	previous_assignee text not null,
+	assignee text not null,
+	comment text not null default '',
+	created_at bigint not null
+);
+create index alert_history_alert_id
+	on alert_history (alert_id);
+-- an alert marked a false positive stops the detector alerting on travel to or from its location for the user.
+create table suppressions
+(
+	id bigserial
+		constraint suppressions_pk
+			primary key,
+	alert_id bigint not null
+		constraint suppressions_alert_id
+			unique
+		constraint suppressions_alert_id_fk
+			references alerts (id),
+	username text not null,
+	lat double precision not null,
+	lon double precision not null,
+	radius int not null,
+	created_at bigint not null
+);
+create index suppressions_username
+	on suppressions (username);
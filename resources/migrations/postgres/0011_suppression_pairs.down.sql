@@ -0,0 +1,7 @@
+delete from suppressions where id not in (select min(id) from suppressions group by alert_id);
+alter table suppressions drop constraint suppressions_alert_id_leg;
+alter table suppressions drop column neighbour_radius;
+alter table suppressions drop column neighbour_lon;
+alter table suppressions drop column neighbour_lat;
+alter table suppressions drop column leg;
+alter table suppressions add constraint suppressions_alert_id unique (alert_id);
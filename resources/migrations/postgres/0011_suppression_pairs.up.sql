@@ -0,0 +1,24 @@
+-- a suppression is the pair of locations of a leg of travel the user was found to make, so marking one alert a false
+-- positive doesn't stop alerts on every other trip to or from either end. There is a row for each leg, and the
+-- existing suppressions become pairs with the neighbours of their alert's event.
+alter table suppressions drop constraint suppressions_alert_id;
+alter table suppressions add column leg text;
+alter table suppressions add column neighbour_lat double precision;
+alter table suppressions add column neighbour_lon double precision;
+alter table suppressions add column neighbour_radius int;
+insert into suppressions(alert_id, leg, username, lat, lon, radius, neighbour_lat, neighbour_lon, neighbour_radius,
+	created_at)
+	select s.alert_id, l.leg, s.username, s.lat, s.lon, s.radius, e.lat, e.lon, greatest(coalesce(e.radius, 0), 50),
+		s.created_at
+	from suppressions s
+		join alerts a on a.id = s.alert_id
+		cross join (values ('preceding'), ('subsequent')) as l (leg)
+		join login_events e on e.event_uuid =
+			case l.leg when 'preceding' then a.preceding_event_uuid else a.subsequent_event_uuid end
+	where e.lat is not null and e.lon is not null and e.location_source != 'unknown';
+delete from suppressions where leg is null;
+alter table suppressions alter column leg set not null;
+alter table suppressions alter column neighbour_lat set not null;
+alter table suppressions alter column neighbour_lon set not null;
+alter table suppressions alter column neighbour_radius set not null;
+alter table suppressions add constraint suppressions_alert_id_leg unique (alert_id, leg);
@@ -0,0 +1,2 @@
+drop index if exists login_events_username_timestamp;
+drop table if exists login_events;
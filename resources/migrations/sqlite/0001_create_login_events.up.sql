@@ -0,0 +1,44 @@
+-- events are append only, every login is kept and event_uuid makes inserts idempotent.
+-- "if not exists" lets this adopt databases created before migrations were versioned.
+create table if not exists login_events
+(
+	id INTEGER
+		constraint login_events_pk
+			primary key autoincrement,
+	event_uuid text not null
+		constraint login_events_event_uuid
+			unique,
+	username text not null,
+	timestamp int not null,
+	lat real,
+	lon real,
+	radius int,
+	ip text,
+	anonymous boolean,
+	verdict blob
+);
+create index if not exists login_events_username_timestamp
+	on login_events (username, timestamp, event_uuid);
+-- before events were append only the events table kept the latest login per user and address. Those rows are
+-- carried over so there is history to compare travel against after upgrading, with an event id made from the old id.
+create table if not exists events
+(
+	id INTEGER
+		constraint events_pk
+			primary key autoincrement,
+	username text,
+	timestamp int,
+	lat real,
+	lon real,
+	radius int,
+	ip text,
+	anonymous boolean,
+	constraint events_pk_2
+		unique (username, ip)
+);
+insert into login_events (event_uuid, username, timestamp, lat, lon, radius, ip, anonymous)
+select 'legacy-' || id, username, timestamp, lat, lon, radius, ip, anonymous
+from events
+where username is not null and timestamp is not null
+on conflict (event_uuid) do nothing;
+drop table events;
@@ -0,0 +1,24 @@
+-- sqlite can't drop columns, so rebuild the table as it was in 0001.
+create table login_events_0001
+(
+	id INTEGER
+		constraint login_events_pk
+			primary key autoincrement,
+	event_uuid text not null
+		constraint login_events_event_uuid
+			unique,
+	username text not null,
+	timestamp int not null,
+	lat real,
+	lon real,
+	radius int,
+	ip text,
+	anonymous boolean,
+	verdict blob
+);
+insert into login_events_0001(id, event_uuid, username, timestamp, lat, lon, radius, ip, anonymous, verdict)
+	select id, event_uuid, username, timestamp, lat, lon, radius, ip, anonymous, verdict from login_events;
+drop table login_events;
+alter table login_events_0001 rename to login_events;
+create index login_events_username_timestamp
+	on login_events (username, timestamp, event_uuid);
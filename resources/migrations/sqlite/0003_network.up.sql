@@ -0,0 +1,3 @@
+-- the autonomous system the login came from, asn is 0 when it wasn't looked up.
+alter table login_events add column asn int not null default 0;
+alter table login_events add column as_org text not null default '';
@@ -0,0 +1,4 @@
+-- non routable and unlocatable addresses are stored with location_source 'unknown' and meaningless coordinates.
+-- everything stored before this was a geolocated public address.
+alter table login_events add column ip_class text not null default 'public';
+alter table login_events add column location_source text not null default 'geoip';
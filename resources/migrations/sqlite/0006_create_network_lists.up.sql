@@ -0,0 +1,16 @@
+-- cidr allow and deny lists, an empty username makes the entry global.
+create table network_lists
+(
+	id INTEGER
+		constraint network_lists_pk
+			primary key autoincrement,
+	list text not null
+		constraint network_lists_list
+			check (list in ('allow', 'deny')),
+	cidr text not null,
+	username text not null default '',
+	comment text not null default '',
+	created_at int not null
+);
+create index network_lists_username
+	on network_lists (username);
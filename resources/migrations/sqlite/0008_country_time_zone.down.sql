@@ -0,0 +1,39 @@
+-- sqlite can't drop columns, so rebuild the table as it was in 0007.
+create table login_events_0007
+(
+	id INTEGER
+		constraint login_events_pk
+			primary key autoincrement,
+	event_uuid text not null
+		constraint login_events_event_uuid
+			unique,
+	username text not null,
+	timestamp int not null,
+	lat real,
+	lon real,
+	radius int,
+	ip text,
+	anonymous boolean,
+	verdict blob,
+	anonymity_checked boolean not null default false,
+	anonymous_vpn boolean not null default false,
+	hosting_provider boolean not null default false,
+	public_proxy boolean not null default false,
+	tor_exit_node boolean not null default false,
+	asn int not null default 0,
+	as_org text not null default '',
+	ip_class text not null default 'public',
+	location_source text not null default 'geoip',
+	location_label text not null default '',
+	location_precision text not null default ''
+);
+insert into login_events_0007(id, event_uuid, username, timestamp, lat, lon, radius, ip, anonymous, verdict,
+	anonymity_checked, anonymous_vpn, hosting_provider, public_proxy, tor_exit_node, asn, as_org,
+	ip_class, location_source, location_label, location_precision)
+	select id, event_uuid, username, timestamp, lat, lon, radius, ip, anonymous, verdict,
+		anonymity_checked, anonymous_vpn, hosting_provider, public_proxy, tor_exit_node, asn, as_org,
+		ip_class, location_source, location_label, location_precision from login_events;
+drop table login_events;
+alter table login_events_0007 rename to login_events;
+create index login_events_username_timestamp
+	on login_events (username, timestamp, event_uuid);
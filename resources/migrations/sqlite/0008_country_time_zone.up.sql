@@ -0,0 +1,3 @@
+-- the iso country code and time zone geoip gave the location, used to score new countries and unusual hours.
+alter table login_events add column country text not null default '';
+alter table login_events add column time_zone text not null default '';
@@ -0,0 +1,21 @@
+-- an alert for every event a rule fired for, reasons is the JSON array of reasons when it was raised.
+create table alerts
+(
+	id INTEGER
+		constraint alerts_pk
+			primary key autoincrement,
+	event_uuid text not null
+		constraint alerts_event_uuid
+			unique,
+	username text not null,
+	event_timestamp int not null,
+	preceding_event_uuid text not null default '',
+	subsequent_event_uuid text not null default '',
+	score int not null,
+	severity text not null,
+	status text not null default 'open',
+	reasons text not null,
+	created_at int not null
+);
+create index alerts_username_event_timestamp
+	on alerts (username, event_timestamp);
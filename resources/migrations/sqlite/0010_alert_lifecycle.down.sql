@@ -0,0 +1,32 @@
+drop index if exists suppressions_username;
+drop table if exists suppressions;
+drop index if exists alert_history_alert_id;
+drop table if exists alert_history;
+-- sqlite can't drop columns, so rebuild the table as it was in 0009.
+create table alerts_0009
+(
+	id INTEGER
+		constraint alerts_pk
+			primary key autoincrement,
+	event_uuid text not null
+		constraint alerts_event_uuid
+			unique,
+	username text not null,
+	event_timestamp int not null,
+	preceding_event_uuid text not null default '',
+	subsequent_event_uuid text not null default '',
+	score int not null,
+	severity text not null,
+	status text not null default 'open',
+	reasons text not null,
+	created_at int not null
+);
+insert into alerts_0009(id, event_uuid, username, event_timestamp, preceding_event_uuid, subsequent_event_uuid,
+	score, severity, status, reasons, created_at)
+	select id, event_uuid, username, event_timestamp, preceding_event_uuid, subsequent_event_uuid,
+		score, severity, status, reasons, created_at from alerts;
+drop index if exists alerts_username_event_timestamp;
+drop table alerts;
+alter table alerts_0009 rename to alerts;
+create index alerts_username_event_timestamp
+	on alerts (username, event_timestamp);
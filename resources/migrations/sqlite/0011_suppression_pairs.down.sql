@@ -0,0 +1,25 @@
+-- sqlite can't drop columns, so rebuild the table as it was in 0010, keeping one location per alert.
+create table suppressions_0010
+(
+	id INTEGER
+		constraint suppressions_pk
+			primary key autoincrement,
+	alert_id int not null
+		constraint suppressions_alert_id
+			unique
+		constraint suppressions_alert_id_fk
+			references alerts (id),
+	username text not null,
+	lat real not null,
+	lon real not null,
+	radius int not null,
+	created_at int not null
+);
+insert into suppressions_0010(alert_id, username, lat, lon, radius, created_at)
+	select alert_id, username, lat, lon, radius, created_at from suppressions
+	where id in (select min(id) from suppressions group by alert_id);
+drop index if exists suppressions_username;
+drop table suppressions;
+alter table suppressions_0010 rename to suppressions;
+create index suppressions_username
+	on suppressions (username);
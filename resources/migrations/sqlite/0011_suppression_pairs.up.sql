@@ -0,0 +1,39 @@
+-- a suppression is the pair of locations of a leg of travel the user was found to make, so marking one alert a false
+-- positive doesn't stop alerts on every other trip to or from either end. sqlite can't change constraints, so the
+-- table is rebuilt with a row for each leg, and the existing suppressions become pairs with the neighbours of their
+-- alert's event.
+create table suppressions_0011
+(
+	id INTEGER
+		constraint suppressions_pk
+			primary key autoincrement,
+	alert_id int not null
+		constraint suppressions_alert_id_fk
+			references alerts (id),
+	leg text not null,
+	username text not null,
+	lat real not null,
+	lon real not null,
+	radius int not null,
+	neighbour_lat real not null,
+	neighbour_lon real not null,
+	neighbour_radius int not null,
+	created_at int not null,
+	constraint suppressions_alert_id_leg
+		unique (alert_id, leg)
+);
+insert into suppressions_0011(alert_id, leg, username, lat, lon, radius, neighbour_lat, neighbour_lon, neighbour_radius,
+	created_at)
+	select s.alert_id, l.leg, s.username, s.lat, s.lon, s.radius, e.lat, e.lon, max(coalesce(e.radius, 0), 50),
+		s.created_at
+	from suppressions s
+		join alerts a on a.id = s.alert_id
+		join (select 'preceding' as leg union all select 'subsequent') l
+		join login_events e on e.event_uuid =
+			case l.leg when 'preceding' then a.preceding_event_uuid else a.subsequent_event_uuid end
+	where e.lat is not null and e.lon is not null and e.location_source != 'unknown';
+drop index if exists suppressions_username;
+drop table suppressions;
+alter table suppressions_0011 rename to suppressions;
+create index suppressions_username
+	on suppressions (username);
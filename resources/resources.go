@@ -1,6 +1,50 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// schemas/eventrequest.json (831B)
+// migrations/postgres/0001_create_login_events.down.sql (89B)
+// migrations/postgres/0001_create_login_events.up.sql (719B)
+// migrations/postgres/0002_anonymity.down.sql (391B)
+// migrations/postgres/0002_anonymity.up.sql (730B)
+// migrations/postgres/0003_network.down.sql (87B)
+// migrations/postgres/0003_network.up.sql (217B)
+// migrations/postgres/0004_ip_class.down.sql (101B)
+// migrations/postgres/0004_ip_class.up.sql (339B)
+// migrations/postgres/0005_location_label.down.sql (53B)
+// migrations/postgres/0005_location_label.up.sql (166B)
+// migrations/postgres/0006_create_network_lists.down.sql (81B)
+// migrations/postgres/0006_create_network_lists.up.sql (440B)
+// migrations/postgres/0007_location_precision.down.sql (57B)
+// migrations/postgres/0007_location_precision.up.sql (187B)
+// migrations/postgres/0008_country_time_zone.down.sql (94B)
+// migrations/postgres/0008_country_time_zone.up.sql (252B)
+// migrations/postgres/0009_create_alerts.down.sql (83B)
+// migrations/postgres/0009_create_alerts.up.sql (626B)
+// migrations/postgres/0010_alert_lifecycle.down.sql (244B)
+// migrations/postgres/0010_alert_lifecycle.up.sql (1.21kB)
+// migrations/postgres/0011_suppression_pairs.down.sql (445B)
+// migrations/postgres/0011_suppression_pairs.up.sql (1.56kB)
+// migrations/sqlite/0001_create_login_events.down.sql (89B)
+// migrations/sqlite/0001_create_login_events.up.sql (1.34kB)
+// migrations/sqlite/0002_anonymity.down.sql (770B)
+// migrations/sqlite/0002_anonymity.up.sql (601B)
+// migrations/sqlite/0003_network.down.sql (1.15kB)
+// migrations/sqlite/0003_network.up.sql (214B)
+// migrations/sqlite/0004_ip_class.down.sql (1.24kB)
+// migrations/sqlite/0004_ip_class.up.sql (339B)
+// migrations/sqlite/0005_location_label.down.sql (1.38kB)
+// migrations/sqlite/0005_location_label.up.sql (166B)
+// migrations/sqlite/0006_create_network_lists.down.sql (81B)
+// migrations/sqlite/0006_create_network_lists.up.sql (449B)
+// migrations/sqlite/0007_location_precision.down.sql (1.45kB)
+// migrations/sqlite/0007_location_precision.up.sql (187B)
+// migrations/sqlite/0008_country_time_zone.down.sql (1.54kB)
+// migrations/sqlite/0008_country_time_zone.up.sql (252B)
+// migrations/sqlite/0009_create_alerts.down.sql (83B)
+// migrations/sqlite/0009_create_alerts.up.sql (632B)
+// migrations/sqlite/0010_alert_lifecycle.down.sql (1.17kB)
+// migrations/sqlite/0010_alert_lifecycle.up.sql (1.19kB)
+// migrations/sqlite/0011_suppression_pairs.down.sql (863B)
+// migrations/sqlite/0011_suppression_pairs.up.sql (1.6kB)
+// schemas/eventrequest.json (892B)
 
 package resources
 
@@ -69,7 +113,887 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _schemasEventrequestJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x52\x4d\x8b\xdb\x30\x10\xbd\xe7\x57\x0c\x6a\x8f\x49\x9d\x26\xd9\x75\xea\x5b\xa1\x3d\x04\x0a\x5d\x0a\x3d\x95\x12\x54\x79\x6c\xcf\x12\x7d\xac\x34\x4a\x1d\xca\xfe\xf7\x62\xcb\x1b\xd7\x18\x52\xf6\xfa\xe6\xcd\xfb\xd0\xe8\xcf\x02\x40\xbc\x0d\xaa\x41\x2d\x45\x01\xa2\x61\x76\x45\x96\x3d\x06\x6b\x56\x09\x7d\x67\x7d\x9d\x95\x5e\x56\xbc\x5a\xdf\x67\x09\x7b\x23\x96\xdd\x1e\x13\x9f\xb0\xdb\xfa\x7c\x46\xc3\xf0\x0d\x9f\x22\x06\x4e\xb3\x12\x83\xf2\xe4\x98\xac\x19\x19\x3e\x31\xc0\xc9\xcb\xc9\xca\x12\x82\x43\x45\x15\x29\xd9\xd3\x92\xe6\xc5\xf5\x92\xf6\xd7\x23\xaa\x41\xcb\x79\xeb\xd0\x33\x61\x10\x05\x74\x89\x01\x44\x0c\xe8\x8d\xd4\x28\xe0\x05\x9a\x9b\x7e\x1f\x38\x50\x59\x0f\xdc\x50\x00\xec\x63\x28\x6b\x18\xdb\x24\xde\xef\xbd\x98\x06\xf6\x64\xea\x11\xd7\x64\xbe\xa0\xa9\xb9\x11\x05\xbc\xef\xc1\xe7\x34\x13\xbd\xd0\x31\x46\x2a\xc5\x0d\x7f\x43\x4f\x11\xaf\xad\x0f\x9f\xfe\xef\x58\x59\xaf\x25\x77\x93\x5e\x7b\xe2\x49\xee\x28\xcb\xd2\x63\x08\x37\x3c\xbf\x7a\xaa\xc9\x48\x26\x53\xc3\xe1\x01\x3e\xa6\x05\x38\x3c\x9c\x77\x60\xcd\xe9\xf2\x9a\x08\xe4\xce\xbb\x69\x84\x68\xa8\x3d\x32\x69\x0c\x2c\xb5\xbb\x5d\xbd\x85\x2b\x11\x6c\x05\xbf\x1b\x34\xff\x1e\xc1\x2a\x15\xbd\xc7\x72\x1e\x88\x0c\x63\x8d\x7e\x72\x06\xd2\x51\x8b\x02\x56\x1f\x36\x9b\xed\x36\xdf\xac\xb7\xf7\xfb\xbb\x5d\x9e\xdf\xed\xd7\xfb\x91\x26\xdb\x81\x36\x67\xe5\xa9\xc6\x62\xa8\x22\xba\x9b\x50\xe7\x5e\xc0\x8f\xf1\x2f\x2d\x27\x87\x5d\x4e\x9e\x7c\x39\x6b\xff\x73\xf1\xfc\x37\x00\x00\xff\xff\xc9\x56\xc3\xba\x3f\x03\x00\x00")
+var _migrationsPostgres0001CreateLoginEventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x59\x00\xa6\xff\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x5f\x75\x73\x65\x72\x6e\x61\x6d\x65\x5f\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x3b\x0a\x03\x00\xb9\xe2\x03\xdf\x59\x00\x00\x00")
+
+func migrationsPostgres0001CreateLoginEventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0001CreateLoginEventsDownSql,
+		"migrations/postgres/0001_create_login_events.down.sql",
+	)
+}
+
+func migrationsPostgres0001CreateLoginEventsDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0001CreateLoginEventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0001_create_login_events.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa1, 0x8a, 0xc, 0xb3, 0x78, 0xd5, 0xb0, 0xb9, 0x99, 0x27, 0x86, 0xef, 0x5a, 0x1, 0x4a, 0x81, 0x18, 0x58, 0x23, 0xa2, 0xe4, 0xb7, 0xff, 0xe0, 0x42, 0x55, 0x6e, 0x3b, 0x12, 0x95, 0x60, 0x3a}}
+	return a, nil
+}
+
+var _migrationsPostgres0001CreateLoginEventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x52\x41\x6e\xdc\x30\x0c\x3c\x4b\xaf\x20\x72\x4a\x00\x25\x1f\xc8\xb1\x0f\x31\x68\x8b\x5d\x13\x96\x49\x57\xa4\xb3\xeb\xdf\x17\xd2\xc2\xdd\x2c\x50\xe4\x66\x8f\x86\xc3\x99\x91\xde\xdf\x81\xbe\x48\xdc\x00\x2b\x01\x6e\x1b\x49\x06\x95\x72\xa4\x86\xd7\x03\x8a\x5e\x58\x80\x0d\x16\xda\x1c\x50\x72\xc3\xc5\x87\x7d\xe7\x0c\x2b\x2e\x64\xc0\x62\x54\xdd\x80\x33\xad\x9b\x3a\x89\x7f\xc4\x53\xf7\xce\x63\x83\x49\x4b\x41\xa7\x0c\x2f\xbf\x5e\xc0\x14\x9c\xc9\x40\x05\x9c\x57\x32\xc7\x75\xeb\x06\xc6\xaa\x0b\x09\x8c\x87\xd3\x95\x8d\x12\xf8\x4c\x60\xb8\x12\xa0\xf5\x6f\xf5\x99\x2a\x8c\x38\x2d\x24\xd9\x52\xdb\x53\xb1\x63\x3e\x63\x1b\x84\xeb\x8c\xde\xac\x43\xd1\x09\x0b\xf5\xa9\x8c\x8e\x23\x1a\xc1\x15\x0d\xa6\x4a\xdd\xc8\x95\x7d\xfe\x88\xf7\x3f\x70\x1c\x0b\x01\xff\x06\x51\x07\xba\xb1\xb9\xdd\xa3\x0f\x3d\xae\xc5\xd7\x18\x38\xc3\xc8\x17\xa3\xca\x58\x62\x08\x93\x8a\x79\x45\x16\x7f\x62\x0e\xdb\x12\x43\x08\x5b\xe5\x15\xeb\x01\x0b\x1d\x29\x86\x6f\x5d\x38\xdd\xfc\x6c\xa3\x97\xd1\x36\xca\x5e\x7e\x92\x7c\x8c\x37\xe9\x5d\xf8\xcf\x4e\x29\x86\xdd\xa8\x4a\x2b\xa7\x6b\x9e\x3a\x29\x86\x47\xa9\x23\x5f\x58\x9e\xce\x0a\x3a\x64\xdd\x5b\xdc\xad\xd2\xc4\xc6\x2a\x0d\x56\xf9\x1f\x5c\x31\xf3\xde\xae\xd8\xe9\x42\x35\xc5\xc0\x5b\xdf\x96\x62\x40\x51\x39\x56\xdd\x0d\x46\xd5\x42\xd8\xe8\x5f\x54\x33\x4f\xde\x2f\x10\xe3\xdb\xe7\x59\x2f\x4b\xa6\xdb\x0f\xf5\x0e\x67\x94\xe1\x9f\xf5\x18\x54\x9e\x38\xf0\x7a\x92\xd2\xe3\xd5\xa4\x6f\xcf\xec\xed\x33\xfe\x1d\x00\xe2\x02\x22\x38\xcf\x02\x00\x00")
+
+func migrationsPostgres0001CreateLoginEventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0001CreateLoginEventsUpSql,
+		"migrations/postgres/0001_create_login_events.up.sql",
+	)
+}
+
+func migrationsPostgres0001CreateLoginEventsUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0001CreateLoginEventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0001_create_login_events.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x34, 0x7f, 0x66, 0x77, 0xe3, 0xbb, 0xf3, 0xff, 0xc4, 0x95, 0x35, 0xe4, 0x76, 0xbe, 0xe4, 0x79, 0x0, 0xaf, 0x39, 0xa6, 0x5a, 0x11, 0x5c, 0xf6, 0x2b, 0x27, 0x7c, 0x41, 0x35, 0xb5, 0x31, 0xea}}
+	return a, nil
+}
+
+var _migrationsPostgres0002AnonymityDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcf\x4b\xae\xc3\x20\x0c\x85\xe1\x79\x56\xe1\x7d\x64\x31\x16\x01\xdf\xc4\xba\x8e\x8d\xc0\xa0\xb0\xfb\xaa\x0f\x75\x58\xd1\x8e\xcf\xf9\x06\x7f\x10\xa7\x02\x1e\x36\x21\x10\xdb\x59\x91\x3a\xa9\x57\x78\x0e\xd1\xa4\x9d\x0a\x41\x4d\xc7\x69\xad\x42\x2a\x96\x41\xcd\x41\x9b\xc8\xba\xfc\xc4\x13\xfd\x85\x26\xfe\x41\x3f\x6e\x2f\xec\x56\x90\x2e\x76\x54\x4b\x34\x69\x72\xdb\x84\x23\xe6\x62\xd7\x98\x24\x87\x55\x67\xdd\xef\xa6\x73\xa2\x32\xc9\xde\x69\xd8\xb3\x7e\x65\xd8\x07\xc6\x83\xe2\x3f\xa5\x75\xb9\x0d\x00\x74\x0d\x95\xb5\x87\x01\x00\x00")
+
+func migrationsPostgres0002AnonymityDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0002AnonymityDownSql,
+		"migrations/postgres/0002_anonymity.down.sql",
+	)
+}
+
+func migrationsPostgres0002AnonymityDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0002AnonymityDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0002_anonymity.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xde, 0x40, 0xbc, 0x48, 0x34, 0x64, 0x44, 0x13, 0x53, 0x25, 0xbc, 0x18, 0x70, 0x43, 0x41, 0x26, 0xc8, 0xfe, 0xd6, 0x98, 0xb2, 0x46, 0xd9, 0x80, 0xca, 0x59, 0xe5, 0x80, 0x78, 0x9c, 0xec, 0x2f}}
+	return a, nil
+}
+
+var _migrationsPostgres0002AnonymityUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xd1\x6d\x6e\xf3\x20\x0c\xc0\xf1\xef\x3d\x85\x0f\xf0\xf4\xb9\x40\xb5\xb3\x20\x07\x9c\xc6\x9a\x6b\x23\x6c\xd2\xe6\xf6\x53\xba\x48\xdd\xa6\xbd\x4a\xf9\x0a\xf2\x8f\x3f\x70\x3c\x42\x4c\x04\x63\x17\x01\x54\xd3\xe5\x62\xdd\x81\x2b\x64\x41\x77\x1e\x39\x63\xb0\xe9\xbf\x6d\x93\x63\x49\x79\xa2\xfc\x4c\x05\xd8\x61\x44\x71\x82\xd1\x1a\xd0\x4c\x1a\x0e\x4e\xa4\x70\xe5\x98\xac\x07\xa0\xbe\x27\x0b\x06\x0e\xe8\xf4\xff\x80\x12\xd4\x20\x70\x10\x02\xb1\x33\x6b\xda\xe6\xb1\x14\xc8\x26\xfd\xa2\x9f\x1c\x38\x98\x09\xa1\x82\x5a\x80\xae\xc1\x85\x46\xec\x12\xaf\x19\xa7\x3f\xa8\xd6\x3d\xcd\x55\x77\x11\x27\xf3\x60\x3d\xa7\xda\x6c\xe6\x42\x6d\x17\xb4\xf6\x41\x38\xaf\xe6\x6d\xd9\x05\x0c\x6b\x89\x6e\x1c\x49\xad\xd0\x8f\x62\xaf\x05\xe3\x03\xe6\x14\x8f\xd7\x83\xa7\xed\xef\xaf\x13\x35\x7a\xb3\xce\x7e\x07\xbf\x8b\xba\xd7\x6e\x59\x8f\xc1\x95\xff\xf5\xbd\xbe\x26\xd4\x02\xb4\x8b\x9c\x0e\x2f\x03\x00\xde\xe0\xb5\x0b\xda\x02\x00\x00")
+
+func migrationsPostgres0002AnonymityUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0002AnonymityUpSql,
+		"migrations/postgres/0002_anonymity.up.sql",
+	)
+}
+
+func migrationsPostgres0002AnonymityUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0002AnonymityUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0002_anonymity.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe0, 0x59, 0x1, 0x57, 0x52, 0xa9, 0xfe, 0x87, 0x83, 0xdf, 0xb1, 0xae, 0xaa, 0x46, 0x3e, 0x6d, 0xbf, 0xa0, 0x71, 0xa5, 0xa8, 0x1e, 0x26, 0x8d, 0x33, 0x7b, 0xf7, 0x63, 0x5f, 0x95, 0x66, 0xb}}
+	return a, nil
+}
+
+var _migrationsPostgres0003NetworkDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x57\x00\xa8\xff\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x61\x73\x5f\x6f\x72\x67\x3b\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x61\x73\x6e\x3b\x0a\x03\x00\xcb\x93\x0f\x3a\x57\x00\x00\x00")
+
+func migrationsPostgres0003NetworkDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0003NetworkDownSql,
+		"migrations/postgres/0003_network.down.sql",
+	)
+}
+
+func migrationsPostgres0003NetworkDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0003NetworkDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0003_network.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8e, 0x11, 0xf, 0xdb, 0x4b, 0x5c, 0x36, 0xae, 0x62, 0x72, 0x97, 0x9e, 0x79, 0x5, 0x72, 0xce, 0x42, 0x2b, 0x44, 0xe6, 0x7e, 0x9d, 0x23, 0x50, 0xef, 0xf, 0x58, 0x4e, 0x1c, 0xc3, 0x2b, 0xa1}}
+	return a, nil
+}
+
+var _migrationsPostgres0003NetworkUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcc\xb1\xae\x83\x30\x0c\x46\xe1\x9d\xa7\xf8\x37\x96\xcb\x15\x3b\x0f\x83\x0c\x31\x10\xd5\xb1\xab\xd8\x29\xed\xdb\x57\x42\x1d\x3b\x74\x3d\xd2\xf9\x86\x01\x71\x30\xa8\x85\xa9\x15\x6b\x0e\x7f\x79\x70\xb9\xaa\xd8\x9e\x15\x2b\x15\xc6\x56\xad\xfc\x81\x5c\x91\x1d\x23\xce\x83\x15\x39\x70\x92\x6b\x1f\x10\xb3\x1b\x27\xb4\xfb\x7f\x47\x12\x5c\x11\xb4\xc8\xe7\x9f\xf9\xc1\x1a\x0e\x4a\x09\xab\x49\x2b\x7a\x31\x4b\xde\xb3\x06\xd4\x02\xda\x44\x90\x78\xa3\x26\x81\x71\xfa\x91\x98\xad\xee\x08\x7e\x7e\x31\xfa\x7e\xea\xde\x03\x00\x98\x6e\xe9\xf4\xd9\x00\x00\x00")
+
+func migrationsPostgres0003NetworkUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0003NetworkUpSql,
+		"migrations/postgres/0003_network.up.sql",
+	)
+}
+
+func migrationsPostgres0003NetworkUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0003NetworkUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0003_network.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdb, 0x5c, 0xa3, 0xcd, 0x8b, 0xf0, 0x80, 0xa3, 0x8a, 0x9c, 0x29, 0x40, 0x27, 0xab, 0x4a, 0xcc, 0x2f, 0x4f, 0xcf, 0x9c, 0xe2, 0x99, 0x10, 0x90, 0xcb, 0xc5, 0x5d, 0xe0, 0xb8, 0x27, 0x3f, 0x26}}
+	return a, nil
+}
+
+var _migrationsPostgres0004IpClassDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x65\x00\x9a\xff\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x6c\x6f\x63\x61\x74\x69\x6f\x6e\x5f\x73\x6f\x75\x72\x63\x65\x3b\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x69\x70\x5f\x63\x6c\x61\x73\x73\x3b\x0a\x03\x00\x05\xec\x16\x10\x65\x00\x00\x00")
+
+func migrationsPostgres0004IpClassDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0004IpClassDownSql,
+		"migrations/postgres/0004_ip_class.down.sql",
+	)
+}
+
+func migrationsPostgres0004IpClassDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0004IpClassDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0004_ip_class.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xeb, 0xbf, 0x32, 0x4, 0xe7, 0x54, 0x5c, 0x1e, 0x39, 0xfc, 0x64, 0xa2, 0xa7, 0xa0, 0x74, 0x2e, 0xbf, 0xe5, 0x6d, 0xdb, 0xf4, 0x8f, 0x16, 0xa8, 0x72, 0x62, 0xfe, 0xf5, 0xac, 0x8b, 0xbb, 0xd7}}
+	return a, nil
+}
+
+var _migrationsPostgres0004IpClassUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcf\xc1\x6a\xc3\x40\x0c\x04\xd0\xbb\xbf\x62\x6e\x3e\x39\x3f\x90\x8f\x31\xeb\xdd\x89\xbd\x54\x91\xcc\x4a\x1b\xb7\x7f\x5f\xea\x34\x97\x42\x21\x47\xc1\xf0\x34\x33\x4d\x50\x53\x34\xeb\x91\x16\x21\x92\x16\x74\x15\xcb\xe9\xf7\x2e\xa5\xd1\x9d\x8e\xd4\x08\x0f\x6b\x2c\x38\x6a\x6c\x38\x33\xd5\x74\x76\xeb\x2d\x13\x63\xd7\x0f\xb5\x43\xc7\xd3\xb8\x33\x69\xd5\x55\xe8\x8e\x6c\xd6\x4a\xd5\x14\xf4\xcb\x30\x4d\xe0\x83\xed\x2b\xb6\xaa\xeb\x0b\x5c\x78\xb3\x46\xc4\x56\x1d\x47\x72\x24\xac\xb4\xf3\x01\x0b\xf6\xbe\x48\xcd\xaf\x26\x97\x21\x49\xb0\xe1\x59\x4f\x6c\xad\x3a\xf3\x41\x0d\xff\x49\x20\x9b\xf4\xbb\xa2\xee\x73\x96\xe4\x8e\xe0\x67\x40\x2d\xa0\x5d\x04\x85\xb7\xd4\x25\x30\x3e\xcd\xf1\xfa\x16\xf6\x77\xe9\x3f\xe6\x4a\xab\xfb\x78\x1d\xbe\x07\x00\x77\x30\xe6\xd5\x53\x01\x00\x00")
+
+func migrationsPostgres0004IpClassUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0004IpClassUpSql,
+		"migrations/postgres/0004_ip_class.up.sql",
+	)
+}
+
+func migrationsPostgres0004IpClassUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0004IpClassUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0004_ip_class.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb4, 0x61, 0x8a, 0x78, 0x77, 0x1d, 0x88, 0xd2, 0x83, 0x8a, 0xcb, 0x7f, 0x61, 0x45, 0xf4, 0x1e, 0x20, 0x4, 0xd8, 0xa4, 0x1a, 0x8b, 0x96, 0x8c, 0x7f, 0xee, 0xff, 0x25, 0x60, 0xf, 0xc4, 0x5e}}
+	return a, nil
+}
+
+var _migrationsPostgres0005LocationLabelDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x35\x00\xca\xff\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x6c\x6f\x63\x61\x74\x69\x6f\x6e\x5f\x6c\x61\x62\x65\x6c\x3b\x0a\x03\x00\x4b\x02\xf8\x2e\x35\x00\x00\x00")
+
+func migrationsPostgres0005LocationLabelDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0005LocationLabelDownSql,
+		"migrations/postgres/0005_location_label.down.sql",
+	)
+}
+
+func migrationsPostgres0005LocationLabelDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0005LocationLabelDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0005_location_label.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd8, 0xd1, 0x2b, 0xb1, 0xfb, 0x20, 0xa6, 0xa7, 0x3b, 0xbc, 0x82, 0x35, 0xda, 0x48, 0x99, 0x27, 0x12, 0x52, 0x9c, 0x10, 0x17, 0xde, 0x20, 0xcb, 0xc5, 0x36, 0xc5, 0x4b, 0xc3, 0x5, 0x70, 0x65}}
+	return a, nil
+}
+
+var _migrationsPostgres0005LocationLabelUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\xcc\x3d\xb2\x83\x30\x0c\x45\xe1\x9e\x55\xdc\x8e\xe6\xf1\x36\x90\xc5\x30\x02\x5f\x7e\x26\xb2\xc4\x18\x39\x84\xdd\x67\xe2\x22\xf5\x99\xef\x0c\x03\x62\x23\xa2\xd4\x33\x98\x60\x8c\xcb\xcb\x13\x62\xe0\x8b\x16\xb8\xe4\x84\xfa\x2c\xdf\x38\xdd\x7f\x60\x3e\xe2\xc6\xb5\xd1\x9a\x6b\x69\x77\xc3\x2c\x99\x58\x8a\x67\xac\xf4\xfd\xf8\xef\x44\x83\x05\x21\x93\x12\xea\xeb\x6e\x63\x1b\x9e\x90\x94\x30\xbb\xd6\x6c\x3f\x3d\xaa\x4c\x54\x04\xdf\x01\xf3\x80\x55\x55\x24\x2e\x52\x35\xd0\xf7\x8f\xee\x33\x00\x4a\xc4\x12\x4e\xa6\x00\x00\x00")
+
+func migrationsPostgres0005LocationLabelUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0005LocationLabelUpSql,
+		"migrations/postgres/0005_location_label.up.sql",
+	)
+}
+
+func migrationsPostgres0005LocationLabelUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0005LocationLabelUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0005_location_label.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1c, 0xe3, 0x15, 0xe9, 0x38, 0x51, 0x20, 0xab, 0x88, 0x3, 0xc6, 0x36, 0xc1, 0x5d, 0x7c, 0x17, 0x48, 0x49, 0x8f, 0x40, 0x41, 0x31, 0x58, 0x41, 0x90, 0x5d, 0x7a, 0xe8, 0x88, 0x64, 0x9, 0x9b}}
+	return a, nil
+}
+
+var _migrationsPostgres0006CreateNetworkListsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x51\x00\xae\xff\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6e\x65\x74\x77\x6f\x72\x6b\x5f\x6c\x69\x73\x74\x73\x5f\x75\x73\x65\x72\x6e\x61\x6d\x65\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6e\x65\x74\x77\x6f\x72\x6b\x5f\x6c\x69\x73\x74\x73\x3b\x0a\x03\x00\x35\x33\x7d\xa7\x51\x00\x00\x00")
+
+func migrationsPostgres0006CreateNetworkListsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0006CreateNetworkListsDownSql,
+		"migrations/postgres/0006_create_network_lists.down.sql",
+	)
+}
+
+func migrationsPostgres0006CreateNetworkListsDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0006CreateNetworkListsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0006_create_network_lists.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x88, 0xa6, 0xf2, 0x94, 0x80, 0x5e, 0x1e, 0xef, 0x9e, 0x4e, 0xee, 0x52, 0xaf, 0xef, 0xf3, 0xae, 0x51, 0x3, 0xf8, 0x84, 0x40, 0x56, 0x85, 0x92, 0xeb, 0x0, 0x83, 0xb1, 0x5e, 0x59, 0xb, 0x5c}}
+	return a, nil
+}
+
+var _migrationsPostgres0006CreateNetworkListsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x41\x8e\xc3\x20\x0c\x45\xd7\xf8\x14\x7f\x47\x22\xa5\x73\x81\x1e\xa6\x72\x83\xa7\x45\x01\x53\x81\xa3\x36\xb7\x1f\x11\xa9\x33\xca\x2c\xba\x44\x7e\x3c\xeb\xf9\x74\xc2\x1c\x43\x05\xa7\x54\x9e\x60\x0d\x08\xa2\x1b\x52\x6c\xd6\x26\xb0\x42\xf2\xc3\x36\xac\x4d\xaa\x72\x16\x64\x5e\xa4\xc1\xee\x02\x51\xab\x1b\x6e\xa9\x5c\x39\x7d\xd1\x5c\x85\x4d\x60\x7c\x4d\x02\x15\x7b\x96\xba\x5c\x76\x0b\x0d\xe4\x62\xc0\x35\xde\x9a\xd4\xc8\x89\x9c\x9b\x8b\x36\xab\x1c\xd5\x8e\xe8\xe5\xb1\x90\x73\xee\x51\x63\xe6\xba\x61\x91\x6d\x22\xd7\x25\x30\x79\x19\xb4\x18\x74\x4d\x1f\x0d\x9d\xee\x8e\xf9\x2e\xf3\x82\xa1\x3f\x11\x15\x83\xdf\x03\xfd\x04\xdf\xfb\xfc\x38\x4e\xe4\xf6\xf0\x83\x79\x22\xf7\x5b\x7a\x18\x20\xc8\x37\xaf\xc9\xe0\x7d\xff\x58\x72\x16\xb5\x8f\xc8\x7e\x8f\x70\x61\xeb\xe5\x51\xff\x38\x1a\xcf\xef\x6b\x45\x0d\xf2\xfa\x17\xf0\xde\x4f\xae\xe8\x71\x84\x61\x6d\x52\x95\xb3\x8c\x67\xfa\x19\x00\x7b\x5d\xe3\xf3\xb8\x01\x00\x00")
+
+func migrationsPostgres0006CreateNetworkListsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0006CreateNetworkListsUpSql,
+		"migrations/postgres/0006_create_network_lists.up.sql",
+	)
+}
+
+func migrationsPostgres0006CreateNetworkListsUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0006CreateNetworkListsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0006_create_network_lists.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3e, 0x26, 0x54, 0xa9, 0xc3, 0xb9, 0x39, 0x0, 0xe9, 0x3f, 0xdb, 0x93, 0xdd, 0x3c, 0xff, 0x94, 0x73, 0x1f, 0x2e, 0x87, 0x8d, 0xa9, 0x5b, 0x70, 0x35, 0x6e, 0x5, 0x81, 0xc0, 0xb7, 0xac, 0x75}}
+	return a, nil
+}
+
+var _migrationsPostgres0007LocationPrecisionDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x39\x00\xc6\xff\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x6c\x6f\x63\x61\x74\x69\x6f\x6e\x5f\x70\x72\x65\x63\x69\x73\x69\x6f\x6e\x3b\x0a\x03\x00\xff\x17\xd7\x36\x39\x00\x00\x00")
+
+func migrationsPostgres0007LocationPrecisionDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0007LocationPrecisionDownSql,
+		"migrations/postgres/0007_location_precision.down.sql",
+	)
+}
+
+func migrationsPostgres0007LocationPrecisionDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0007LocationPrecisionDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0007_location_precision.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x21, 0x9a, 0x44, 0xde, 0x2, 0x33, 0x6b, 0xd5, 0xbd, 0x43, 0xc9, 0xb1, 0x3b, 0x35, 0x1f, 0x6f, 0xaf, 0x8a, 0x1c, 0x8c, 0xd0, 0xf4, 0xc9, 0x92, 0xe1, 0x95, 0x9c, 0x67, 0x43, 0x29, 0x65, 0x9a}}
+	return a, nil
+}
+
+var _migrationsPostgres0007LocationPrecisionUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\xce\x31\x6e\x85\x30\x10\x84\xe1\x9e\x53\x4c\x47\x03\x5c\x20\x87\x41\x8e\x3d\x80\xa5\x65\x17\xad\xd7\x49\xb8\x7d\x84\x90\x5e\x3b\x53\x7c\xff\x3c\xe3\xb0\x5f\x5c\xce\x5c\x1b\x11\x07\xb1\xd3\xea\x05\xb1\x9c\xa2\x9a\xa2\xb6\x09\xb9\xc6\x3d\xc1\xb9\x3f\x83\x39\x32\x35\xdc\x6a\x59\xc0\xf3\x8a\x1b\x9b\x39\x2c\x0e\x3a\x9a\x75\xcf\x6c\x48\x5a\x60\x52\xe8\xe0\x0f\x35\xda\x32\x24\x09\x3a\x22\x7d\x0b\x21\xb6\x57\x5d\xdf\x07\xa9\x14\x64\x93\x7e\xea\x07\x5d\xdf\x9e\x47\x0b\xfe\x05\xd4\x02\xda\x45\x50\xb8\xa5\x2e\x81\x71\xfc\x1a\xfe\x07\x00\xd2\x51\x1c\xe3\xbb\x00\x00\x00")
+
+func migrationsPostgres0007LocationPrecisionUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0007LocationPrecisionUpSql,
+		"migrations/postgres/0007_location_precision.up.sql",
+	)
+}
+
+func migrationsPostgres0007LocationPrecisionUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0007LocationPrecisionUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0007_location_precision.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x55, 0x6f, 0x48, 0xa9, 0xdc, 0x8c, 0xe3, 0xd1, 0xf1, 0xde, 0xc0, 0x58, 0xa6, 0xce, 0x52, 0xf3, 0xbe, 0xb6, 0xb5, 0x59, 0x35, 0x32, 0xae, 0xe, 0x4d, 0x14, 0x5, 0xed, 0x1e, 0xf8, 0x7, 0x20}}
+	return a, nil
+}
+
+var _migrationsPostgres0008CountryTimeZoneDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5e\x00\xa1\xff\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x74\x69\x6d\x65\x5f\x7a\x6f\x6e\x65\x3b\x0a\x61\x6c\x74\x65\x72\x20\x74\x61\x62\x6c\x65\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x20\x64\x72\x6f\x70\x20\x63\x6f\x6c\x75\x6d\x6e\x20\x63\x6f\x75\x6e\x74\x72\x79\x3b\x0a\x03\x00\x32\x69\xac\x23\x5e\x00\x00\x00")
+
+func migrationsPostgres0008CountryTimeZoneDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0008CountryTimeZoneDownSql,
+		"migrations/postgres/0008_country_time_zone.down.sql",
+	)
+}
+
+func migrationsPostgres0008CountryTimeZoneDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0008CountryTimeZoneDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0008_country_time_zone.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0x31, 0xab, 0x6a, 0x5d, 0xe5, 0xd7, 0xc3, 0xed, 0x61, 0x10, 0x4c, 0xc4, 0xaf, 0xb9, 0xfa, 0xf4, 0xb1, 0x84, 0x4a, 0x7d, 0xc7, 0xd6, 0x74, 0x60, 0x71, 0x5b, 0x86, 0xde, 0x30, 0x2a, 0x7}}
+	return a, nil
+}
+
+var _migrationsPostgres0008CountryTimeZoneUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\x41\x6a\xc3\x40\x0c\x85\xe1\x7d\x4f\xf1\x76\xde\xd4\xbd\x40\x0f\x63\xd4\x99\x57\x7b\x40\x96\xca\x8c\xe4\xb6\x39\x7d\x88\x09\xd9\x05\xb2\xff\xf9\xf8\xe7\x19\xb1\x11\x6d\x38\x8a\xa7\x45\xff\x47\xf1\x4a\x88\x55\x44\xdb\x89\x8b\x1b\xb1\xd2\xdb\x0f\x56\x39\x78\xd6\xea\x45\xa2\xb9\xbd\x23\x07\x2b\xc2\x31\x8a\x77\xc2\xf8\x7b\x57\x1a\xc7\x49\xa4\xe5\x48\x51\x6c\x9e\x7d\x7c\xbc\x89\x06\x3b\x42\xbe\x94\x50\x5f\x9b\x2d\x3c\x68\x31\x20\xb5\xa2\xb8\xe6\x6e\x8f\x8d\xe0\x5f\xc0\x3c\x60\xa9\x8a\xca\x6f\x49\x0d\x4c\xd3\xe7\x4b\xca\xed\x7d\x39\xdf\x9f\x3b\xd7\x01\x00\xc6\x05\x9c\x58\xfc\x00\x00\x00")
+
+func migrationsPostgres0008CountryTimeZoneUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0008CountryTimeZoneUpSql,
+		"migrations/postgres/0008_country_time_zone.up.sql",
+	)
+}
+
+func migrationsPostgres0008CountryTimeZoneUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0008CountryTimeZoneUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0008_country_time_zone.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6, 0x35, 0x9f, 0xd, 0xff, 0x85, 0xdf, 0x17, 0xda, 0x38, 0x19, 0xe6, 0xdf, 0x23, 0xc2, 0xc3, 0x8e, 0x91, 0x62, 0x92, 0x34, 0x1, 0x1e, 0x57, 0x81, 0x26, 0x94, 0xa9, 0x7c, 0x33, 0xb1, 0xf4}}
+	return a, nil
+}
+
+var _migrationsPostgres0009CreateAlertsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x53\x00\xac\xff\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x61\x6c\x65\x72\x74\x73\x5f\x75\x73\x65\x72\x6e\x61\x6d\x65\x5f\x65\x76\x65\x6e\x74\x5f\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x61\x6c\x65\x72\x74\x73\x3b\x0a\x03\x00\xd7\x43\x2a\xab\x53\x00\x00\x00")
+
+func migrationsPostgres0009CreateAlertsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0009CreateAlertsDownSql,
+		"migrations/postgres/0009_create_alerts.down.sql",
+	)
+}
+
+func migrationsPostgres0009CreateAlertsDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0009CreateAlertsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0009_create_alerts.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf7, 0x91, 0x84, 0xf, 0x76, 0x11, 0xf, 0x6a, 0x75, 0x28, 0x4a, 0xc4, 0x41, 0x7, 0x1c, 0xea, 0x5e, 0xc4, 0x19, 0x90, 0xfc, 0xd7, 0xb8, 0xf, 0x4f, 0xfa, 0x9b, 0xe3, 0x25, 0xdd, 0x1a, 0x30}}
+	return a, nil
+}
+
+var _migrationsPostgres0009CreateAlertsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x72\xf2\x30\x0c\x85\xd7\xf6\x29\xb4\x03\x66\xc2\x7f\x01\x6e\xf0\x2f\xda\x45\x0f\x90\x11\xb1\x02\x1a\x1c\x3b\x48\x72\x21\xb7\xef\x18\x1a\x3a\x64\xca\x4c\x37\xd9\xe8\xe9\xd3\xcb\x7b\xde\x6e\x01\x13\x60\x24\x31\xe8\xb3\x00\x7d\x92\x4c\xf5\x9b\x0c\x10\xa4\x44\x82\x9e\x85\x42\x1d\x36\x20\x84\x9a\x93\x02\x2b\xd8\x91\xe0\xff\xc7\xfb\x1b\xa0\x08\x4e\x90\xfb\xc7\xf0\x72\xa4\x04\x6c\x70\x41\x05\x41\x56\x0a\xff\x7c\x27\x84\x46\x60\xb8\x8f\x74\xbf\xa6\x7e\xed\x1d\x07\xd8\xf3\x41\x49\x18\xa3\x77\xae\xcb\x49\x4d\x90\xeb\xed\x9b\xa6\x1d\x4f\xde\x39\x37\x0a\x0f\x28\x13\x9c\x68\x6a\xbc\xbb\x99\x6b\x4b\xe1\x00\x46\x57\x83\x94\x0d\x52\x89\xbf\x03\x7e\xc4\x15\x54\x12\x9f\x0b\x35\xde\x15\x25\x49\x38\xd0\x33\xe1\x01\x37\x1e\x48\x0d\x87\xb1\xda\xe3\xf4\xa4\x18\x85\x3a\x0a\x9c\x0e\xed\x2b\x23\x10\xa8\xc7\x12\x0d\x56\xab\xc6\x3b\x2d\x7b\xa5\x73\xa9\x36\xfe\xb8\xd0\x65\x21\x58\x5c\xd5\x5a\x0c\xdb\xf4\xbc\x58\x07\x86\x56\xf4\x15\x2f\x8f\x94\x2a\x73\xee\x66\xb9\x7d\xef\x25\xb4\x68\xcb\x3f\xf5\x9b\xdd\xdc\x1a\xa7\x40\xd7\x39\xd0\x39\xb9\x76\x91\x94\x77\xf9\xfb\x1d\x29\xac\x67\x51\x03\x0b\xd5\x66\xe7\xbf\x06\x00\x8a\xc5\x1a\xb2\x72\x02\x00\x00")
+
+func migrationsPostgres0009CreateAlertsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0009CreateAlertsUpSql,
+		"migrations/postgres/0009_create_alerts.up.sql",
+	)
+}
+
+func migrationsPostgres0009CreateAlertsUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0009CreateAlertsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0009_create_alerts.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x59, 0x7c, 0xec, 0xdb, 0x1a, 0x39, 0x19, 0x6d, 0xb5, 0x18, 0xed, 0x3c, 0xc1, 0xef, 0x45, 0xfa, 0x85, 0xba, 0xfd, 0x1b, 0xf9, 0x4d, 0xfa, 0xd9, 0xf2, 0x30, 0x36, 0x80, 0xe9, 0x4, 0xe7, 0x70}}
+	return a, nil
+}
+
+var _migrationsPostgres0010AlertLifecycleDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\xcc\x41\x0a\x02\x31\x0c\x85\xe1\x7d\x4f\x91\x7b\xf4\x30\x25\xda\xa7\x06\x3a\x6d\xc9\x4b\x61\xbc\xbd\x30\xba\x11\x1c\x5c\x86\x7c\xef\xaf\x3e\xa6\x58\xaf\xd8\xc5\x6e\x82\xdd\x18\x14\xae\x39\x1d\xa4\x8d\xce\xb2\x08\xef\xba\x21\xa7\xc3\x86\x5e\x1a\x4e\x6c\x4e\x3f\x73\xda\xe0\x51\x1e\xc6\x18\xfe\x2c\xef\xcb\xea\x49\xef\x0b\xe7\xa4\x2d\xe0\x1f\x74\xbc\x28\xc7\xec\x3a\xda\xda\xba\xac\x59\x35\x50\x8b\xc6\x5f\xaa\xa4\xdd\x3b\x90\xd3\x6b\x00\x54\x93\x8f\x37\xf4\x00\x00\x00")
+
+func migrationsPostgres0010AlertLifecycleDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0010AlertLifecycleDownSql,
+		"migrations/postgres/0010_alert_lifecycle.down.sql",
+	)
+}
+
+func migrationsPostgres0010AlertLifecycleDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0010AlertLifecycleDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0010_alert_lifecycle.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7f, 0x1a, 0x99, 0x37, 0xb1, 0x9, 0x30, 0xa7, 0xbc, 0x4e, 0x21, 0x3b, 0xb6, 0xcd, 0xd3, 0x62, 0x2f, 0x94, 0x96, 0xa2, 0xab, 0x32, 0xff, 0x67, 0xfc, 0x1e, 0x96, 0x7f, 0xf, 0x7, 0x7b, 0xbb}}
+	return a, nil
+}
+
+var _migrationsPostgres0010AlertLifecycleUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x52\xd1\x6e\xdb\x30\x0c\x7c\xb6\xbe\x82\x6f\x4d\x80\xb4\xd8\x7b\x3e\x26\x60\x2d\x26\x26\x22\x93\x1e\x49\xa5\xf5\xdf\x0f\x4a\xe7\xa2\x6e\x9b\x6c\xc3\x5e\x75\xbc\xa3\xee\x78\x8f\x8f\x80\x85\x2c\x1c\xd0\x08\x5e\xd4\xce\x94\xe1\x79\x06\x14\x2c\xb3\x87\xef\x80\x2e\x64\x33\xf4\x03\xca\x89\x20\x14\x54\x08\xd8\xe1\x4c\x53\x00\xcb\x1b\xfb\x30\xb0\x87\xda\xbc\x83\x97\x81\xfb\xa1\xe1\x2a\x65\xbe\x72\x81\xc5\xc9\x82\x32\xb0\x84\x3e\x25\x2c\x41\x06\x81\xcf\x85\xde\x57\xe7\x0c\xbd\x96\x3a\x0a\xa0\x3b\x9f\x84\x08\x82\x5e\x03\x44\x03\xa4\x96\x02\x99\x8e\x58\x4b\xc0\xc3\xc3\xfe\x0f\x02\x75\xca\x18\x94\x0f\x18\xf0\xcc\x27\x96\x6f\x44\x7e\xec\x53\x6f\x84\x41\x1f\x45\x16\x0b\x69\x93\x3a\xce\x8d\xeb\x64\x8c\x25\x75\x5d\xaf\xe2\x61\xd8\xb4\x56\xa3\x87\xe9\x9c\xba\xae\x9b\x8c\x47\xb4\x19\xce\x34\xef\x52\xf7\x36\xc1\xf9\xf3\xf6\x7b\x3a\x0b\xa7\xa9\x19\x1d\xc9\x48\x7a\xf2\xc5\xdc\x86\xf3\xb6\x09\xf7\xa1\xb6\x8e\x65\x97\xba\xc9\xe8\xc2\x5a\xfd\xe0\x81\x51\xfd\x0b\x7e\xe3\xf9\x9d\xf6\x7d\xde\x6d\xdd\x2d\xa0\xd7\x71\x24\x89\x9b\x07\xda\xa5\xee\x2d\xdd\xef\x6e\x90\xb6\xef\xd9\xb3\x64\x7a\xbd\x19\x84\x7e\x6a\x16\x6c\x16\x6c\xbb\x4f\xad\xb4\xbf\x71\x18\xf1\x5a\x59\x84\x23\x16\x27\x98\xd4\x39\xf8\x42\xe0\xa1\x93\x43\x0c\x04\x99\x82\xae\xd9\x5d\x09\x2c\x27\x50\x81\x30\xbc\x50\xb9\xf6\xd9\xe0\x68\x3a\x02\x87\x43\xd1\x1e\x83\x55\xe0\xd8\xa2\x1e\x08\xaa\x93\x3d\xad\xdb\xe2\x75\x9a\x8c\xdc\x59\xc5\xef\x97\xe5\xe3\xe4\x7f\x74\x65\x25\xb3\x50\x5a\x55\xaa\xf0\xcf\x4a\x7f\x31\x7c\x38\x9e\xef\x56\xab\xb9\x14\x1c\xbf\xde\xba\x60\x40\xd6\xda\x6c\x4f\x46\x3d\x37\xd3\x2b\x5c\xe5\x2e\x6e\x98\xb9\x3a\x7c\xf4\xf6\x6f\xf5\x58\xd9\x59\xbe\x99\x3a\x95\x15\x02\x9b\xea\x64\x82\x23\x6d\xf7\xe9\xd7\x00\x72\xac\x79\x19\xd2\x04\x00\x00")
+
+func migrationsPostgres0010AlertLifecycleUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0010AlertLifecycleUpSql,
+		"migrations/postgres/0010_alert_lifecycle.up.sql",
+	)
+}
+
+func migrationsPostgres0010AlertLifecycleUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0010AlertLifecycleUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0010_alert_lifecycle.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb0, 0x54, 0xc7, 0xce, 0xdc, 0x67, 0x89, 0x3a, 0xd7, 0x19, 0xdc, 0x39, 0x6e, 0x98, 0x9d, 0x42, 0xa6, 0x8f, 0x98, 0xa5, 0x15, 0x47, 0x92, 0x75, 0x40, 0x71, 0x6a, 0xe2, 0x9d, 0x48, 0x83, 0x2b}}
+	return a, nil
+}
+
+var _migrationsPostgres0011SuppressionPairsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xd0\x41\xae\x83\x40\x08\x80\xe1\xbd\xa7\x60\xa9\x67\xf0\x30\x13\x14\x9e\x92\x20\xcc\x63\x98\x34\xbd\x7d\x57\x5d\x98\x34\xb5\xae\xe1\xfb\x13\x20\x56\x4e\x86\xbf\xf0\x03\x5a\xaf\x35\xb8\x35\x71\x6b\xf0\xd8\x39\x18\x84\xc0\x3c\x41\x0c\xc6\xc6\xca\x6b\xc2\x21\x36\x0a\x4d\x1f\xc4\x16\xde\x2b\x2c\x4f\x40\xe5\xc8\x22\x34\xcd\x03\x6a\x72\x40\xe2\xa2\x7c\x5e\xa6\xf0\x0a\xab\x5b\xcb\x40\xb1\x3c\x0d\xcb\x3b\x50\x94\xb7\xeb\x86\xf6\xc3\xc0\x58\xb6\x7d\xf1\x1e\x25\x90\xa4\xb7\xdb\x4c\xdd\xee\x1b\xcc\x1f\xcd\xf7\x43\x90\xe8\xf2\x17\xd0\x4d\xfe\x3b\xc3\x88\xca\x91\x45\x68\x9a\x87\xd7\x00\xbe\x55\x4f\xf2\xbd\x01\x00\x00")
+
+func migrationsPostgres0011SuppressionPairsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0011SuppressionPairsDownSql,
+		"migrations/postgres/0011_suppression_pairs.down.sql",
+	)
+}
+
+func migrationsPostgres0011SuppressionPairsDownSql() (*asset, error) {
+	bytes, err := migrationsPostgres0011SuppressionPairsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0011_suppression_pairs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x30, 0x80, 0x16, 0xb7, 0xa3, 0x75, 0x2a, 0x6e, 0x12, 0x4c, 0x8e, 0x51, 0xdf, 0xbf, 0xe4, 0xa6, 0x13, 0xf2, 0xaf, 0xf3, 0x92, 0xc0, 0xbd, 0x64, 0x7c, 0x3b, 0xda, 0x25, 0x66, 0xf8, 0x72, 0xd6}}
+	return a, nil
+}
+
+var _migrationsPostgres0011SuppressionPairsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x54\x4d\x6f\xab\x4a\x0c\x5d\xc3\xaf\xf0\x5b\x11\x24\x82\xba\x79\xab\xa8\xff\xe2\xed\x91\xc3\x38\x61\x5e\x27\x36\x1d\x7b\x92\xde\x7f\x7f\x35\x43\x93\x82\xee\x57\xaf\xba\xc3\xc6\x3e\xe7\xd8\x1c\xb3\xdf\x03\x82\xa6\x79\x8e\xa4\xea\x85\xc1\x2b\xd8\x44\x30\xa3\x8f\x20\x27\x08\x32\xa2\x79\x61\xcd\x01\x42\xa0\x73\x7e\xb0\x88\x57\x0a\xa5\x30\x29\x45\xb8\xa1\xc2\x49\x12\x3b\x30\x81\x0b\xbe\x50\x07\x9a\x1f\xe2\x8b\xe7\x33\x08\x13\x60\xa0\x68\x80\x70\xc2\xa0\x54\xef\xf7\x30\x8b\x7a\xf3\x57\x02\x27\xa4\xdc\x18\xa8\xc9\xbc\x94\x29\x08\x03\x5d\x29\x7e\x03\xb1\x89\x22\x58\xf4\x73\x46\x96\x08\xa7\x28\x17\x20\x5f\xd2\xc4\xae\x87\xff\x26\x8a\x94\x55\x23\x44\xb9\xc1\x49\x22\x10\x8e\x53\x56\xda\x01\x66\x45\x53\xe1\xa3\x37\xaf\x96\xd5\xac\x86\x55\x38\xd2\x28\x97\x65\x5a\x85\x9b\xb7\x29\x97\x03\x93\x3f\x4f\x47\x49\xb1\x4c\x6d\x13\xf9\xb8\x28\x6b\x34\xeb\x62\xeb\x6b\x0c\x96\x85\xe1\x31\xd0\x16\xd1\x45\x99\x61\x14\x56\x8b\xe8\xd9\x36\x2f\x87\x02\x32\x78\x77\xf8\x75\x3f\x3a\x07\xa3\x84\x74\xe1\x3c\x02\x18\xbd\xd9\xe7\xaa\x1f\xa2\x87\x80\x06\x4e\x52\x2e\x9e\x23\x8d\x3e\x03\xff\x35\x86\xf0\x97\x31\x22\x3a\x9f\x14\x3c\xdb\xa1\xf6\xac\xd9\x00\x9e\x4d\x36\x9d\xbb\xfb\x4a\xba\x3c\x6e\x57\xec\xc4\x78\xa1\x0e\x02\x5a\x07\x41\xb8\x83\x05\xa7\x5b\xab\x43\xdb\x84\xc2\xeb\xf0\xbd\xbe\xae\xc6\x48\x68\xe4\x06\xb4\xb6\xae\x94\x02\x8d\x06\xda\xaf\x18\xfb\xc2\xa9\xfd\x07\xab\xf6\x85\x57\xfb\xc2\xac\xfd\x9d\x9b\x96\x3c\x2d\xf9\x73\x01\x56\xdb\x8d\x82\x81\x74\xa4\x1d\x3d\x2a\x9f\xda\x0e\xfe\x7d\x6a\xbb\xba\xaa\xb4\xff\x50\x50\x57\xc5\xba\x9b\xad\x69\x5d\x55\xff\x8b\xe7\xbb\xed\x31\x1b\x1f\x7b\xef\xe0\x79\xa5\xb3\xae\xaa\x31\x8a\x2a\x94\xd2\xdd\x15\x43\x22\x85\x5d\x93\xbf\x0b\x39\xcf\xe7\xa6\xed\x60\xd7\x68\x3a\x2a\xbd\x26\x62\x6b\xda\x16\x50\x21\xc0\x2e\xd0\xb9\xbd\x73\x04\x39\x7b\x1e\x8a\x7d\x15\x28\x33\x51\x5f\xa2\x21\xa5\xcc\x58\x57\x55\x35\xa2\xd2\xb2\x15\xb8\x4d\xc4\xb0\xe2\xc8\x87\x91\xc5\x3d\x32\xc3\xaa\x99\x82\x12\x60\xff\x21\x61\xf3\x92\x5d\x5d\xdd\xca\x99\x96\x2d\xe6\x63\x65\x31\xe0\x14\x42\xb9\xd0\xb2\xd4\x9f\x66\x97\x7f\xcf\xa0\x92\xe2\x48\xf0\xcf\x33\x34\x89\x5f\x58\x6e\xdc\x1c\x6a\x47\x81\x8c\xe0\xc7\xad\x2e\x54\x79\x86\x0c\x99\x42\xf8\x9d\x6f\x8b\xa1\xdf\x9d\x9b\x5b\x94\xec\x21\xe3\xb3\x7d\x2b\x23\xa2\x7d\x15\x41\xf8\x8b\x08\xef\x57\xf7\x59\x10\xe7\xfe\xf8\xb7\x1a\xf2\x62\x12\xfb\xd7\x44\xb0\xbd\xd7\xf6\x50\x7f\x1f\x00\x35\x4f\xc2\xf3\x41\x06\x00\x00")
+
+func migrationsPostgres0011SuppressionPairsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsPostgres0011SuppressionPairsUpSql,
+		"migrations/postgres/0011_suppression_pairs.up.sql",
+	)
+}
+
+func migrationsPostgres0011SuppressionPairsUpSql() (*asset, error) {
+	bytes, err := migrationsPostgres0011SuppressionPairsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/postgres/0011_suppression_pairs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x70, 0x54, 0x40, 0x7c, 0x30, 0x4c, 0x56, 0x2a, 0x8d, 0x90, 0xd7, 0xbd, 0x2e, 0xc7, 0xa, 0x5d, 0x33, 0x98, 0xb0, 0x69, 0xa8, 0x79, 0x59, 0x47, 0xaf, 0x47, 0x24, 0x3f, 0x1, 0xe1, 0x3c, 0xb2}}
+	return a, nil
+}
+
+var _migrationsSqlite0001CreateLoginEventsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x59\x00\xa6\xff\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x5f\x75\x73\x65\x72\x6e\x61\x6d\x65\x5f\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6c\x6f\x67\x69\x6e\x5f\x65\x76\x65\x6e\x74\x73\x3b\x0a\x03\x00\xb9\xe2\x03\xdf\x59\x00\x00\x00")
+
+func migrationsSqlite0001CreateLoginEventsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0001CreateLoginEventsDownSql,
+		"migrations/sqlite/0001_create_login_events.down.sql",
+	)
+}
+
+func migrationsSqlite0001CreateLoginEventsDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0001CreateLoginEventsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0001_create_login_events.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa1, 0x8a, 0xc, 0xb3, 0x78, 0xd5, 0xb0, 0xb9, 0x99, 0x27, 0x86, 0xef, 0x5a, 0x1, 0x4a, 0x81, 0x18, 0x58, 0x23, 0xa2, 0xe4, 0xb7, 0xff, 0xe0, 0x42, 0x55, 0x6e, 0x3b, 0x12, 0x95, 0x60, 0x3a}}
+	return a, nil
+}
+
+var _migrationsSqlite0001CreateLoginEventsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x31\x6f\xc3\x36\x10\x85\x67\xf1\x57\x3c\x64\x49\x0c\xc8\x19\xba\x7a\x0e\x8a\x2e\x1d\x8a\xec\xc6\x59\x3c\xdb\x07\x53\x77\x2a\x79\xb2\x63\x20\x3f\xbe\xa0\x6c\x39\x76\x11\xa4\x45\xbb\x09\xe4\xf1\xf1\xe9\xbd\x8f\xcb\x25\xf8\xc8\xea\x05\x94\x19\x34\x0c\xac\x11\xa6\xe9\xdc\xd6\xf5\x7c\x46\xb2\x9d\x28\xa4\xe0\xc0\x83\x83\x34\xd6\x75\xf5\xf5\x38\x4a\x44\x4f\x07\x2e\x10\x2d\x9c\xbd\x40\x22\xf7\x83\x39\xab\xbf\x86\xe5\x12\x4f\xb2\x85\x9a\x83\x3f\xa4\x78\x79\x42\x62\x2f\xf0\xbd\x14\x50\xb4\xc1\x11\xc9\x69\x43\x85\x0b\xba\xcc\xe4\x1c\xb1\xe1\xad\x65\x46\x2f\xbb\x4c\x2e\xa6\x05\x27\xce\x8c\x23\xe7\x22\xa6\x1c\x5f\xc3\x65\x12\x4e\x9b\xc4\x78\xd0\xbf\x18\x5d\x4f\xe6\x4a\x78\x09\x8d\x44\xfc\xf6\xfb\xfb\xdb\xaf\x6f\x7f\x84\xa6\xe9\x4c\x8b\x67\x12\xf5\x87\xb9\xf5\x70\x08\x4d\xd3\x0c\x59\x7a\xca\x67\x1c\xf8\x0c\x1a\xdd\x44\xbb\xcc\x3d\xab\xb7\xa1\xb9\xfb\x5b\xe7\x0f\x9f\x6e\xd4\x31\xa5\x1f\x44\xbf\x8e\x54\xf1\x51\xe5\xcf\x91\xdb\xd0\x8c\x85\xb3\x52\xcf\x8f\x3a\x6d\x68\x5c\x7a\x2e\x4e\xfd\x00\xd1\x87\x8d\x44\x8e\xcc\x34\x7d\x9a\xce\x9f\x99\xa2\x8c\x35\xf5\x6a\x4f\x86\x49\xae\x0d\x0d\xa9\xe9\xb9\xb7\xb1\x60\x63\x96\x98\xb4\x0d\xcd\x91\x73\x94\xce\xb1\x49\xb6\x09\x8b\xd5\x1c\x9f\x68\xe4\x8f\x1f\xe2\x5b\xcf\x56\xd7\x37\x6b\xa1\x31\x7d\x98\xc1\xcb\x3c\xd4\xe2\x36\xd5\xde\xc1\xb1\x58\x55\x08\xae\x95\x5e\xcf\x9c\xf8\x11\x32\xf8\xfe\xb6\x77\xe9\x74\xa2\xac\xae\x26\x72\x2e\xd7\x60\x31\x70\x46\xbd\x6e\xe2\x8f\x62\xcc\x5c\xca\x2b\xde\xf7\x56\x18\xd9\x4e\x13\xbc\xf5\xb6\x8e\x72\x16\x8e\xb0\x23\x67\x14\xab\xf2\x99\x2b\xbc\x7b\x29\x6e\xf9\x0c\x37\x74\xd6\x0f\x95\x75\xcf\x74\xe4\x04\xda\x91\x68\x71\xd0\xd6\xeb\x25\xc3\xae\xc6\xab\xbb\x16\x27\xf1\x3d\x48\x2f\xf6\x30\xc1\x1e\x19\xdb\x6c\x7d\x95\x85\xa5\x08\xf9\x99\xc8\x7f\x66\xf1\x5f\x53\x38\x67\x3d\x97\x7d\x4b\xfc\x8a\xc1\xff\x26\xe5\x3b\x53\xeb\x5f\xc2\x8c\xef\x7d\xdb\x32\x2c\x2a\x4b\x97\x57\x5f\xc5\xed\x6f\x60\x7c\x31\xd0\xe2\x5b\x48\x12\x79\x8b\x64\xda\xe2\xe2\xb0\x6a\xb6\xb8\xd9\x5a\x84\xc2\x89\x3b\xc7\x73\xe2\x1d\x75\xe7\xe5\x33\x3e\x3f\xf1\x5f\xd5\xc2\x54\xd9\xb5\x8b\xd3\x04\xc4\x2c\x53\xc9\x98\x9f\xdb\x84\xd6\x4d\xf5\x7e\x27\x98\xa2\x33\xdd\xa6\xfa\x92\x5e\xee\x00\x47\xb4\x5a\xf7\x5e\x74\xb7\x0a\x31\xdb\x70\xa5\x80\x8f\xac\x5e\x56\xe1\xaf\x01\x00\x68\xa3\x96\xaf\x5c\x05\x00\x00")
+
+func migrationsSqlite0001CreateLoginEventsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0001CreateLoginEventsUpSql,
+		"migrations/sqlite/0001_create_login_events.up.sql",
+	)
+}
+
+func migrationsSqlite0001CreateLoginEventsUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0001CreateLoginEventsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0001_create_login_events.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x42, 0x1d, 0xe, 0xd0, 0xd4, 0xba, 0x42, 0x91, 0x22, 0x5c, 0x5c, 0xe0, 0x7b, 0x79, 0xfd, 0x2f, 0x9c, 0x63, 0x86, 0xee, 0x8b, 0xc3, 0xc6, 0x20, 0xf0, 0x2a, 0x2e, 0x14, 0xba, 0xe4, 0x1e, 0xd2}}
+	return a, nil
+}
+
+var _migrationsSqlite0002AnonymityDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x3f\x8f\xdb\x30\x0c\xc5\x67\xf1\x53\xbc\xad\x09\xa0\x2b\xd2\xd9\xf3\xa1\xe8\xd2\xa1\xe8\x1e\xc8\x36\xdb\x12\x27\x93\x3e\x89\xba\x26\xdf\xbe\xb0\x5b\x27\x70\xff\x4d\x37\x49\x00\xc9\xc7\x1f\x1f\xde\xc3\x03\xea\x73\x16\x67\x0c\x49\xdf\x38\xc6\x62\x33\x06\xcb\x6d\xd2\x1a\x51\x0d\x85\xfb\x26\x79\x84\x7f\x63\x78\xea\x33\x23\x55\x88\xe3\xfb\xf2\x28\x4e\xa7\xd3\xbb\xb7\x34\x14\x4e\xbe\xd5\xb3\x7d\x15\x3d\xf3\x0b\xab\xd7\xf3\x52\xa7\x03\x05\x19\xf1\xe1\xe3\xe7\xc7\xf7\x8f\x9f\x28\x84\xc1\xb4\x7a\x49\xa2\xbe\x6f\x9e\x9f\x28\x84\x30\x17\x99\x52\xb9\xe2\x89\xaf\x48\xcd\x4d\x74\x28\x3c\xb1\x7a\xa4\xb0\xaa\x9e\x5b\x93\x11\xce\x17\x87\x9a\x43\x5b\xce\xff\x11\xbd\x8f\x2c\xe2\x4d\xe5\xb9\x71\xa4\xd0\x2a\x17\x4d\x13\xef\x75\x22\x05\x97\x89\xab\xa7\x69\x86\xe8\xae\x90\x93\xa3\x70\x5a\xbf\xa6\xdb\xb7\xa4\x51\xda\x62\xc5\x82\x27\xf3\x2a\x17\x29\x24\x35\xbd\x4e\xd6\x2a\x7a\xb3\xcc\x49\x23\x85\x17\x2e\xa3\x0c\x8e\x3e\x5b\x4f\xc7\x8e\x44\x2b\x17\x5f\x46\x6d\x8f\xbc\x98\x76\x90\x31\xe2\xce\x1e\xb1\x01\x47\xdc\x08\x23\x72\xf2\x88\x6c\x1a\xf1\x93\x23\x42\xe6\x88\xdb\xf2\x88\x5f\x3b\x8f\x14\x2a\x67\x1e\x1c\xaf\x2a\x8b\x2f\xc5\xa6\x1d\x7b\x47\x6b\x82\xfe\x4c\x42\x47\x29\x3b\x97\x7f\x65\x04\x85\x17\x0a\xfc\x66\x45\xb7\x45\x4b\x74\xe4\xcb\x7e\x6c\x23\x3f\xdf\xc0\x29\x98\xee\x7a\x70\xf8\xeb\x79\xf7\xfb\x8f\x1d\xfd\x18\x00\x2c\x08\xcf\x6b\x02\x03\x00\x00")
+
+func migrationsSqlite0002AnonymityDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0002AnonymityDownSql,
+		"migrations/sqlite/0002_anonymity.down.sql",
+	)
+}
+
+func migrationsSqlite0002AnonymityDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0002AnonymityDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0002_anonymity.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcd, 0x7e, 0xdb, 0xee, 0x67, 0x96, 0xdb, 0xc8, 0xb0, 0xc0, 0x27, 0x16, 0x95, 0x90, 0xd3, 0xdb, 0xb4, 0x23, 0xd9, 0x97, 0xd7, 0x99, 0x29, 0x5e, 0x35, 0x96, 0xf7, 0xd4, 0xba, 0x26, 0x1a, 0x1e}}
+	return a, nil
+}
+
+var _migrationsSqlite0002AnonymityUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\xd1\x6d\x6a\x33\x41\x08\x07\xf0\xef\x39\x85\x07\x78\xf2\x5c\x20\xf4\x2c\x83\x3b\xe3\x64\xa4\x46\x87\xd1\xd9\x64\x6f\x5f\x42\x17\xfa\x42\xa1\x14\xf6\xab\xe2\xcf\x3f\x7a\x3e\x43\x34\x82\x3a\x45\x00\xd5\x74\xbb\xd9\x74\xe0\x0e\x59\xd0\x9d\x2b\x67\x0c\x36\xfd\xb7\x37\x39\xb6\x94\x1b\xe5\x57\x2a\xc0\x0e\x15\xc5\x09\xaa\x0d\xa0\x95\x34\x1c\x9c\x48\xe1\xce\xd1\x6c\x06\xa0\x7e\x25\x0b\x06\x2e\xe8\xf4\xff\x84\x12\x34\x20\x70\x11\x02\xb1\x2b\x6b\xda\xe7\xb1\x14\xc8\x26\xf3\xa6\x3f\x2c\x5c\xcc\x84\x50\x41\x2d\x40\x9f\x81\x0b\x55\x9c\x12\xef\x31\x2e\x7f\x50\x6d\x7a\x5a\xbb\x1e\x22\x36\xf3\x60\xbd\xa6\x3e\x6c\xe5\x42\xe3\x10\xb4\xcf\x45\x38\x3f\xcd\xc7\x76\x08\x18\x36\x12\x3d\x38\x92\x5a\xa1\x5f\xc5\xd9\x0b\xc6\x37\xcc\x29\x3e\xae\x07\x2f\xfb\xef\xef\x8d\x06\x7d\xaa\xb3\x83\x4e\x91\xcb\xe9\x6d\x00\x38\x99\x67\xd6\x59\x02\x00\x00")
+
+func migrationsSqlite0002AnonymityUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0002AnonymityUpSql,
+		"migrations/sqlite/0002_anonymity.up.sql",
+	)
+}
+
+func migrationsSqlite0002AnonymityUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0002AnonymityUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0002_anonymity.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x95, 0x71, 0xe9, 0xe8, 0x6e, 0xf3, 0xfa, 0xae, 0x9, 0x3d, 0x79, 0x1d, 0x7f, 0x75, 0x60, 0x18, 0x65, 0xe9, 0x4b, 0x7, 0x38, 0x80, 0xf2, 0x40, 0xb0, 0x1d, 0x63, 0x8a, 0x5b, 0xe6, 0x9d, 0x4a}}
+	return a, nil
+}
+
+var _migrationsSqlite0003NetworkDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x93\xb1\x8e\x1b\x3d\x0c\x84\x6b\xe9\x29\xa6\xfb\x6d\x40\xf7\xc3\x48\xeb\xfa\x10\xa4\x49\x11\xa4\x5f\x68\x57\xf4\x99\xb0\x96\xda\x93\x28\xc7\x7e\xfb\x40\x4e\xd6\xf6\x5e\x2e\x71\x73\x95\x05\x70\x38\x26\xbf\x59\x3e\x3d\xa1\xbc\x46\x56\xc2\xe0\xe5\x3f\x45\xc8\x69\xc2\x90\x62\x1d\xa5\x38\x94\x84\x4c\x7d\xe5\x18\xa0\x7b\x82\xfa\x3e\x12\x7c\x01\x2b\x7e\xb4\x1f\xc1\x66\xb3\xf9\xf4\xbf\x1d\x32\x79\x9d\xeb\x31\xbd\xb0\x74\x74\x24\xd1\xd2\xb5\xba\x5d\x59\xc3\x01\x5f\xbe\x7e\x7f\xfe\xfc\xfc\xcd\x1a\x33\x24\x29\x9a\x3d\x8b\x2e\xc5\xd3\xc1\x1a\x63\xa6\xcc\xa3\xcf\x67\x1c\xe8\x0c\x5f\x35\xb1\x0c\x99\x46\x12\x75\xd6\x5c\x5c\xbb\x5a\x39\x40\xe9\xa4\x90\xa4\x90\x1a\xe3\x3f\x4c\x6f\x2d\xcd\xbc\x0a\xbf\x56\x72\xd6\xd4\x42\x59\xfc\x48\x4b\x1f\x67\x8d\xf2\x48\x45\xfd\x38\x81\x65\x51\x88\x5e\x91\xc9\x5f\x9e\x49\xe6\x67\xf6\x81\x6b\x43\xd1\xc6\xe3\xe9\x62\xe7\xac\xf1\x92\xe4\x3c\xa6\x5a\xd0\xa7\x14\xc9\x8b\xb3\xe6\x48\x39\xf0\xa0\xe8\x63\xea\xaf\x12\xd6\x73\x37\xec\x69\x38\x50\x98\xa5\xd7\x3f\x45\xa0\x9d\xaf\x51\xb1\xf3\xb1\xd0\xbd\x6b\x77\x9c\xe4\xb1\x7c\x9f\x8a\xb2\xbc\x74\x53\x4e\x47\x0e\x94\x1f\x77\x4c\xb5\x8f\x3c\xb4\x86\xd3\xf9\xb1\x5a\x53\xee\xe8\xc4\xda\x49\x0a\xf4\x40\x6e\xd7\x5b\xcb\x52\x28\x6b\x63\x95\x96\x19\xb5\xaf\x64\xc5\xc1\xe1\x16\x96\xc3\x9c\x90\xc3\x35\x12\x87\xe8\xd5\x21\x26\x71\xf8\x05\xde\x81\x27\x87\x2b\x17\x87\xdf\x90\xdf\xe3\x7b\xa7\x6b\xfc\x1c\xde\xf2\x71\xb8\xdf\xdf\x61\xb1\xdf\xda\x9a\x42\x91\x06\xc5\x47\x0f\xfa\xd1\x93\x62\x97\xd3\xb8\x00\xbc\xb5\x97\xbb\xfe\xf3\x3e\xb7\xd6\x47\xa5\xfc\xb7\xcb\x45\xa6\xb6\x18\xde\xe4\xb5\x9d\x0f\x9e\x25\xd0\x69\xd9\x36\xc3\xe8\xae\x2c\xac\x49\xb2\xd0\x60\xf5\x2e\xb1\x1b\xd2\xf5\xd6\xfe\x1c\x00\x80\xdd\x23\xea\x98\x04\x00\x00")
+
+func migrationsSqlite0003NetworkDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0003NetworkDownSql,
+		"migrations/sqlite/0003_network.down.sql",
+	)
+}
+
+func migrationsSqlite0003NetworkDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0003NetworkDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0003_network.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xab, 0x6f, 0x79, 0xfc, 0x38, 0xc5, 0x7c, 0x4a, 0xbc, 0x4d, 0x5d, 0x96, 0xaf, 0x1f, 0xf8, 0x7c, 0xc4, 0xc1, 0xf0, 0x66, 0xcd, 0xda, 0x69, 0x17, 0x1b, 0x60, 0xd6, 0x4c, 0xad, 0xce, 0x97, 0x71}}
+	return a, nil
+}
+
+var _migrationsSqlite0003NetworkUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcc\xbd\x0e\x82\x50\x0c\x47\xf1\x9d\xa7\xf8\x6f\x2c\x62\xd8\x79\x18\x52\xb9\xe5\x23\xf6\xb6\x86\xb6\xa2\x6f\x6f\xa2\x8e\x0e\xae\x27\x39\xbf\xae\x43\xac\x0c\xca\x30\xb5\x6a\xe9\xf0\xa7\x07\xd7\x77\x15\x5b\x36\xc5\x44\x95\x31\xef\x56\x4f\x20\x57\x6c\x8e\x1e\xc7\xca\x8a\x2d\x70\x90\x6b\x1b\x10\xb3\x2b\x17\xe4\xed\xdc\x90\x04\xef\x08\xba\xc8\xf7\x1f\xf9\xce\x1a\x0e\x2a\x05\x93\x49\x56\xfd\x30\x1a\x50\x0b\x68\x8a\xa0\xf0\x4c\x29\x81\x7e\xf8\xf3\x1f\x6d\x5f\x10\xfc\xf8\x61\xb4\xed\xd0\xbc\x06\x00\x29\xd5\xcc\x9b\xd6\x00\x00\x00")
+
+func migrationsSqlite0003NetworkUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0003NetworkUpSql,
+		"migrations/sqlite/0003_network.up.sql",
+	)
+}
+
+func migrationsSqlite0003NetworkUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0003NetworkUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0003_network.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd0, 0xeb, 0xe6, 0x33, 0xbd, 0x16, 0x71, 0x35, 0xf7, 0x41, 0x4d, 0x64, 0x2b, 0x52, 0x86, 0x84, 0xd3, 0x30, 0x46, 0xad, 0x25, 0xe7, 0x89, 0x3b, 0x6f, 0xd1, 0xe2, 0x53, 0xd8, 0x59, 0x34, 0x53}}
+	return a, nil
+}
+
+var _migrationsSqlite0004IpClassDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x94\x31\x8f\xdb\x30\x0c\x85\x67\xe9\x57\x70\xbb\x04\xd0\x15\x01\x3a\x66\x3e\x14\x5d\x3a\x14\xdd\x0d\xd9\x62\x12\x22\x32\xe9\x93\xa8\x34\xf9\xf7\x85\xd2\xda\x89\xae\xd7\x66\x69\x97\x44\x80\x1e\x9f\xc9\xef\xd9\x7c\x7e\x86\xfc\x1a\x49\x11\x06\xcf\x4f\x0a\x21\xc9\x04\x83\xc4\x32\x72\x76\x90\x05\x12\xf6\x85\x62\x00\x3d\x20\xa8\xef\x23\x82\xcf\x40\x0a\xdf\xeb\x1f\xc3\x66\xb3\xf9\xf8\xc1\x0e\x09\xbd\xce\xf7\x51\xf6\xc4\x1d\x9e\x90\x35\x77\xf5\xde\xae\xac\xa1\x00\x9f\xbf\x7c\x7b\xf9\xf4\xf2\xd5\x1a\x33\x08\x67\x4d\x9e\x58\x5b\xf1\x74\xb4\xc6\x98\x29\xd1\xe8\xd3\x05\x8e\x78\x01\x5f\x54\x88\x87\x84\x23\xb2\x3a\x6b\xae\xae\x5d\x29\x14\x40\xf1\xac\xc0\xa2\xc0\x25\xc6\xbf\x98\xde\x4a\xaa\x79\x61\x7a\x2d\xe8\xac\x29\x19\x13\xfb\x11\x5b\x1f\x67\x8d\xd2\x88\x59\xfd\x38\x01\x71\x73\x11\xbd\x42\x42\x7f\x3d\x0a\xcf\xc7\xe4\x03\x95\x8a\xa2\xb6\x47\xd3\xd5\xce\x59\xe3\x59\xf8\x32\x4a\xc9\xd0\x8b\x44\xf4\xec\xac\x39\x61\x0a\x34\x28\xf4\x51\xfa\x45\x42\x7a\xe9\x86\x03\x0e\x47\x0c\xb3\x74\x79\x28\x04\xdc\xf9\x12\x15\x76\x3e\x66\xbc\x77\xed\x4e\x13\x3f\x96\x1f\x24\x2b\xf1\xbe\x9b\x92\x9c\x28\x60\x7a\x5c\x31\x95\x3e\xd2\x50\x0b\xce\x97\xc7\x6a\x95\xd4\xe1\x99\xb4\x63\x09\xf8\x58\xee\x33\x37\x4c\x17\xc1\xa6\x8e\x96\x3b\x49\xfb\x36\x8d\x45\xf0\xf4\x64\xd7\x5b\x4b\x9c\x31\x69\xb5\x90\x36\xe2\xfa\x92\xad\x28\x38\xb8\x65\xed\x60\x0e\xd8\xc1\x92\xa8\x83\xe8\xd5\x41\x14\x76\xf0\x33\x37\x07\x34\x39\x58\xb0\x3a\xf8\x95\xd1\x7b\xf1\xdc\xe9\x2a\x7e\x07\x6f\xf1\x3a\xb8\xc7\xe7\xa0\xc1\xe3\xc0\x67\xae\x3f\x75\xcc\xb5\x35\x19\x23\x0e\x0a\xff\xba\xeb\xff\xda\x36\xec\x92\x8c\x0d\xfa\xad\xbd\x2e\x8c\xdf\x3f\xfc\xad\xf5\x51\x31\xfd\x69\x25\x40\xc2\x3a\x25\xbc\x49\x72\x3b\x6f\x12\xe2\x80\xe7\xb6\x6c\x26\xd3\x2d\x60\xac\x11\x6e\x34\xb0\x7a\x17\xdf\x8d\xef\x7a\x6b\x7f\x0c\x00\x0d\x90\x45\xe8\xf1\x04\x00\x00")
+
+func migrationsSqlite0004IpClassDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0004IpClassDownSql,
+		"migrations/sqlite/0004_ip_class.down.sql",
+	)
+}
+
+func migrationsSqlite0004IpClassDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0004IpClassDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0004_ip_class.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xee, 0xbc, 0x20, 0x17, 0x4, 0x82, 0xc6, 0xd3, 0x32, 0x9c, 0x98, 0xf4, 0x5, 0xc8, 0xf, 0x80, 0xd7, 0xfc, 0x2b, 0x3d, 0xde, 0x23, 0xc9, 0xe9, 0x18, 0x65, 0xb6, 0x4e, 0x11, 0x89, 0x44, 0x47}}
+	return a, nil
+}
+
+var _migrationsSqlite0004IpClassUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xcf\xc1\x6a\xc3\x40\x0c\x04\xd0\xbb\xbf\x62\x6e\x3e\x39\x3f\x90\x8f\x31\xeb\xdd\x89\xbd\x54\x91\xcc\x4a\x1b\xb7\x7f\x5f\xea\x34\x97\x42\x21\x47\xc1\xf0\x34\x33\x4d\x50\x53\x34\xeb\x91\x16\x21\x92\x16\x74\x15\xcb\xe9\xf7\x2e\xa5\xd1\x9d\x8e\xd4\x08\x0f\x6b\x2c\x38\x6a\x6c\x38\x33\xd5\x74\x76\xeb\x2d\x13\x63\xd7\x0f\xb5\x43\xc7\xd3\xb8\x33\x69\xd5\x55\xe8\x8e\x6c\xd6\x4a\xd5\x14\xf4\xcb\x30\x4d\xe0\x83\xed\x2b\xb6\xaa\xeb\x0b\x5c\x78\xb3\x46\xc4\x56\x1d\x47\x72\x24\xac\xb4\xf3\x01\x0b\xf6\xbe\x48\xcd\xaf\x26\x97\x21\x49\xb0\xe1\x59\x4f\x6c\xad\x3a\xf3\x41\x0d\xff\x49\x20\x9b\xf4\xbb\xa2\xee\x73\x96\xe4\x8e\xe0\x67\x40\x2d\xa0\x5d\x04\x85\xb7\xd4\x25\x30\x3e\xcd\xf1\xfa\x16\xf6\x77\xe9\x3f\xe6\x4a\xab\xfb\x78\x1d\xbe\x07\x00\x77\x30\xe6\xd5\x53\x01\x00\x00")
+
+func migrationsSqlite0004IpClassUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0004IpClassUpSql,
+		"migrations/sqlite/0004_ip_class.up.sql",
+	)
+}
+
+func migrationsSqlite0004IpClassUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0004IpClassUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0004_ip_class.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb4, 0x61, 0x8a, 0x78, 0x77, 0x1d, 0x88, 0xd2, 0x83, 0x8a, 0xcb, 0x7f, 0x61, 0x45, 0xf4, 0x1e, 0x20, 0x4, 0xd8, 0xa4, 0x1a, 0x8b, 0x96, 0x8c, 0x7f, 0xee, 0xff, 0x25, 0x60, 0xf, 0xc4, 0x5e}}
+	return a, nil
+}
+
+var _migrationsSqlite0005LocationLabelDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x94\x31\x6f\x1b\x31\x0c\x85\x67\xe9\x57\x70\x73\x02\x28\x85\x87\x6e\x9e\x83\xa2\x4b\x87\xa2\xbb\x20\x4b\x8c\x43\x44\x47\x5e\x24\xca\xb5\xff\x7d\x21\xa7\x77\xf1\xb9\x71\xbd\xb4\x8b\x2d\x40\x8f\x3c\xf1\x7b\x0f\x7c\x78\x80\xfa\x9a\x49\x11\x62\xe0\x95\x42\x2a\x32\x42\x94\xdc\x06\xae\x0e\xaa\x40\xc1\x6d\xa3\x9c\x40\x9f\x11\x34\x6c\x33\x42\xa8\x40\x0a\x3f\xfb\x1f\xc3\x7a\xbd\xfe\xfc\xc9\xc6\x82\x41\xa7\xfb\x2c\x3b\x62\x8f\x7b\x64\xad\xbe\xdf\xdb\x3b\x6b\x28\xc1\xd7\x6f\x3f\x1e\xbf\x3c\x7e\xb7\xc6\x44\xe1\xaa\x25\x10\xeb\x52\x3c\xbe\x58\x63\xcc\x58\x68\x08\xe5\x08\x2f\x78\x84\xd0\x54\x88\x63\xc1\x01\x59\x9d\x35\xa7\xae\xbe\x35\x4a\xa0\x78\x50\x60\x51\xe0\x96\xf3\x5f\x9a\xbe\x97\xf4\xe6\x8d\xe9\xb5\xa1\xb3\xa6\x55\x2c\x1c\x06\x5c\xf6\x71\xd6\x28\x0d\x58\x35\x0c\x23\x10\x2f\x2e\x72\x50\x28\x18\x4e\x47\xe1\xe9\x58\x42\xa2\xd6\x51\xf4\xe7\xd1\x78\x6a\xe7\xac\x09\x2c\x7c\x1c\xa4\x55\xd8\x8a\x64\x0c\xec\xac\xd9\x63\x49\x14\x15\xb6\x59\xb6\xb3\x84\xf4\xe8\xe3\x33\xc6\x17\x4c\x93\x74\xfe\x28\x24\x7c\x0a\x2d\x2b\x3c\x85\x5c\xf1\xbc\xab\xdf\x8f\x7c\x5b\xfe\x2c\x55\x89\x77\x7e\x2c\xb2\xa7\x84\xe5\x76\xc5\xd8\xb6\x99\x62\x2f\x38\x1c\x6f\xab\x55\x8a\xc7\x03\xa9\x67\x49\x78\x5b\x1e\x2a\x2f\x98\xce\x82\x75\x1f\xad\x7a\x29\xbb\xa5\x1b\xb3\x60\xb5\x3a\xc1\xf5\x31\x87\x5a\xaf\x69\xde\xde\xde\x95\x59\x62\x50\x12\xf6\x55\x5a\x89\x78\xad\x60\x87\x42\xe3\xca\xde\x6f\x2c\x71\xc5\xa2\xfd\x71\xb2\x0c\x4f\x8f\xef\x1d\x25\x07\xef\x29\x72\x30\x45\xc7\xc1\x9c\x15\x07\x39\xa8\x83\x2c\xec\xe0\x2d\x11\x0e\x68\x74\x30\x1b\xe6\xe0\xb7\xfb\x1f\x19\x7f\xa6\xeb\xc6\x3a\xb8\x34\xce\xc1\xb9\x31\x0e\x16\xe0\x1d\x84\xca\xfd\xa7\x03\x3c\xe3\xe4\xe0\x82\xc3\xbd\x35\x15\x33\x46\x85\x7f\x3d\xd1\xff\x1e\xe9\xfa\x4c\xf0\x54\x64\x58\x78\xb6\xb1\xa7\x1d\xf6\xe7\x2e\xda\xd8\x90\x15\xcb\xb5\x2d\x05\x05\x3b\x02\xb8\x88\xc0\x66\x5a\x6e\xc4\x09\x0f\xcb\xb2\x09\x9b\x9f\xa9\x59\x23\xbc\xd0\xc0\xdd\x87\x6c\xdf\xe1\xdf\x6f\xec\xaf\x01\x00\x31\x8d\x1b\xa7\x84\x05\x00\x00")
+
+func migrationsSqlite0005LocationLabelDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0005LocationLabelDownSql,
+		"migrations/sqlite/0005_location_label.down.sql",
+	)
+}
+
+func migrationsSqlite0005LocationLabelDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0005LocationLabelDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0005_location_label.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc0, 0xec, 0x6b, 0xa2, 0x4c, 0x60, 0x43, 0x6c, 0xef, 0x1e, 0xac, 0x4a, 0x4, 0xa7, 0x18, 0x1, 0x45, 0xcd, 0x74, 0x81, 0x15, 0xe9, 0x19, 0x4d, 0xe1, 0x3a, 0x6f, 0xb8, 0x1c, 0x4, 0xa3, 0xbb}}
+	return a, nil
+}
+
+var _migrationsSqlite0005LocationLabelUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\xcc\x3d\xb2\x83\x30\x0c\x45\xe1\x9e\x55\xdc\x8e\xe6\xf1\x36\x90\xc5\x30\x02\x5f\x7e\x26\xb2\xc4\x18\x39\x84\xdd\x67\xe2\x22\xf5\x99\xef\x0c\x03\x62\x23\xa2\xd4\x33\x98\x60\x8c\xcb\xcb\x13\x62\xe0\x8b\x16\xb8\xe4\x84\xfa\x2c\xdf\x38\xdd\x7f\x60\x3e\xe2\xc6\xb5\xd1\x9a\x6b\x69\x77\xc3\x2c\x99\x58\x8a\x67\xac\xf4\xfd\xf8\xef\x44\x83\x05\x21\x93\x12\xea\xeb\x6e\x63\x1b\x9e\x90\x94\x30\xbb\xd6\x6c\x3f\x3d\xaa\x4c\x54\x04\xdf\x01\xf3\x80\x55\x55\x24\x2e\x52\x35\xd0\xf7\x8f\xee\x33\x00\x4a\xc4\x12\x4e\xa6\x00\x00\x00")
+
+func migrationsSqlite0005LocationLabelUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0005LocationLabelUpSql,
+		"migrations/sqlite/0005_location_label.up.sql",
+	)
+}
+
+func migrationsSqlite0005LocationLabelUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0005LocationLabelUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0005_location_label.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1c, 0xe3, 0x15, 0xe9, 0x38, 0x51, 0x20, 0xab, 0x88, 0x3, 0xc6, 0x36, 0xc1, 0x5d, 0x7c, 0x17, 0x48, 0x49, 0x8f, 0x40, 0x41, 0x31, 0x58, 0x41, 0x90, 0x5d, 0x7a, 0xe8, 0x88, 0x64, 0x9, 0x9b}}
+	return a, nil
+}
+
+var _migrationsSqlite0006CreateNetworkListsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x51\x00\xae\xff\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6e\x65\x74\x77\x6f\x72\x6b\x5f\x6c\x69\x73\x74\x73\x5f\x75\x73\x65\x72\x6e\x61\x6d\x65\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x6e\x65\x74\x77\x6f\x72\x6b\x5f\x6c\x69\x73\x74\x73\x3b\x0a\x03\x00\x35\x33\x7d\xa7\x51\x00\x00\x00")
+
+func migrationsSqlite0006CreateNetworkListsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0006CreateNetworkListsDownSql,
+		"migrations/sqlite/0006_create_network_lists.down.sql",
+	)
+}
+
+func migrationsSqlite0006CreateNetworkListsDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0006CreateNetworkListsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0006_create_network_lists.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x88, 0xa6, 0xf2, 0x94, 0x80, 0x5e, 0x1e, 0xef, 0x9e, 0x4e, 0xee, 0x52, 0xaf, 0xef, 0xf3, 0xae, 0x51, 0x3, 0xf8, 0x84, 0x40, 0x56, 0x85, 0x92, 0xeb, 0x0, 0x83, 0xb1, 0x5e, 0x59, 0xb, 0x5c}}
+	return a, nil
+}
+
+var _migrationsSqlite0006CreateNetworkListsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x90\x31\x6b\xc3\x30\x14\x84\x67\xe9\x57\xdc\x66\x1b\x9c\xfe\x81\xcc\xa1\x74\xe9\x50\xba\x87\x17\xe9\xb5\x11\x96\x9e\x8c\xf4\x4c\xe2\x7f\x5f\x64\x48\x8b\x3b\x64\x11\x88\x3b\x7d\xba\xbb\xc3\x01\x2e\xf8\x02\x8a\x31\xdf\x40\xe2\xe1\x59\x56\xc4\x50\xb5\x8e\x20\x01\xa7\x59\x57\x2c\x95\x8b\x50\x62\x24\x9a\xb8\x42\xaf\x0c\x16\x2d\x2b\xbe\x63\xbe\x50\x7c\xb1\xae\x30\x29\x43\xe9\x12\x19\xc2\x7a\xcb\x65\x3a\x6f\x14\xdb\x5b\x13\x3c\xde\xde\x3f\x4f\xaf\xa7\x0f\x6b\x8c\xcb\x52\xb5\x50\x10\xdd\x1b\xcf\xf3\x64\x8d\x31\x73\x09\x89\xca\x8a\x89\x57\xd0\xa2\x39\x88\x2b\x9c\x58\x74\xb4\xa6\x01\xa1\x7c\x57\x48\x56\xc8\x12\xe3\x33\x5e\x3b\x1b\xd1\x5d\xd9\x4d\xe8\xdb\x15\x41\xd0\x77\x5b\xd9\x6e\x44\xd7\xba\x76\xc3\x30\x5a\xb3\x8d\xb0\x23\x8f\xd6\xfc\xb6\xde\x09\xf0\xfc\x45\x4b\x54\x74\x5d\x7b\x98\x53\x0b\xf7\xd4\xb2\x6d\xe3\xcf\xa4\x08\xf2\x67\xb2\xc3\xf1\x31\x5b\x10\xcf\xf7\x7f\xe9\x1f\x9f\x5b\x93\x65\x2f\xa1\x5f\x2a\x17\xa1\xc4\xc3\xd1\xfe\x0c\x00\x1e\x3a\x56\x51\xc1\x01\x00\x00")
+
+func migrationsSqlite0006CreateNetworkListsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0006CreateNetworkListsUpSql,
+		"migrations/sqlite/0006_create_network_lists.up.sql",
+	)
+}
+
+func migrationsSqlite0006CreateNetworkListsUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0006CreateNetworkListsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0006_create_network_lists.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x12, 0xbc, 0xa1, 0xbd, 0x53, 0x8d, 0x3a, 0x91, 0xe8, 0x2f, 0xc6, 0xb8, 0x83, 0xd3, 0xff, 0xac, 0x12, 0xac, 0x6a, 0x36, 0x13, 0x45, 0x2a, 0x9, 0x14, 0x8f, 0xbf, 0x23, 0x41, 0x32, 0xa5, 0x5}}
+	return a, nil
+}
+
+var _migrationsSqlite0007LocationPrecisionDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x94\x31\x6f\x1b\x3f\x0c\xc5\x67\xe9\x53\x70\x73\x02\x28\x7f\x78\xfa\x2f\x9e\x83\xa2\x4b\x87\xa2\xbb\xa0\x93\x18\x87\x88\x8e\xbc\x48\x94\x6b\x7f\xfb\x42\x4e\x7d\xf6\xa5\x71\xdd\xa1\x5d\x6c\xc1\xfa\x91\x16\xdf\x7b\xe0\xc3\x03\xd4\xd7\x4c\x8a\x10\x03\xaf\x14\x52\x91\x09\xa2\xe4\x36\x72\x75\x50\x05\x0a\x0e\x8d\x72\x02\x7d\x46\xd0\x30\x64\x84\x50\x81\x14\xbe\xf7\x2f\x86\xf5\x7a\xfd\xff\x7f\x36\x16\x0c\x7a\xba\xcf\xb2\x25\xf6\xb8\x43\xd6\xea\xfb\xbd\xbd\xb3\x86\x12\x7c\xfe\xf2\xed\xf1\xd3\xe3\x57\x6b\x4c\x14\xae\x5a\x02\xb1\x2e\xe1\xe9\xc5\x1a\x63\xa6\x42\x63\x28\x07\x78\xc1\x03\x84\xa6\x42\x1c\x0b\x8e\xc8\xea\xac\x39\x76\xf5\xad\x51\x02\xc5\xbd\x02\x8b\x02\xb7\x9c\x7f\xd3\xf4\x5c\xd2\x9b\x37\xa6\xd7\x86\xce\x9a\x56\xb1\x70\x18\x71\xd9\xc7\x59\xa3\x34\x62\xd5\x30\x4e\x40\xbc\xb8\xc8\x41\xa1\x60\x38\x1e\x85\x4f\xc7\x12\x12\xb5\x2e\x45\x7f\x1e\x4d\xc7\x76\xce\x9a\xc0\xc2\x87\x51\x5a\x85\x41\x24\x63\x60\x67\xcd\x0e\x4b\xa2\xa8\x30\x64\x19\x66\x84\xf4\xe0\xe3\x33\xc6\x17\x4c\x27\x74\xfe\x53\x48\xf8\x14\x5a\x56\x78\x0a\xb9\xe2\x65\x57\xbf\x9b\xf8\x36\xfe\x2c\x55\x89\xb7\x7e\x2a\xb2\xa3\x84\xe5\x76\xc5\xd4\x86\x4c\xb1\x17\xec\x0f\xb7\x69\x95\xe2\x71\x4f\xea\x59\x12\xde\xc6\x43\xe5\x85\xa6\x33\xb0\xee\xa3\x55\x2f\x65\xbb\x74\x63\x06\x56\xab\xa3\xb8\x3e\xe6\x50\xeb\x35\xe6\xed\xed\x9d\xcc\x12\x83\x92\xb0\xaf\xd2\x4a\xc4\x6b\x05\x5b\x14\x9a\x16\x7c\x0e\x03\xe6\x6b\xf8\xca\xde\x6f\x2c\x71\xc5\xa2\x7d\x0c\x59\xc6\xac\x07\xfd\x8e\x92\x83\x73\xde\x1c\x9c\x42\xe6\x60\x4e\x95\x83\x1c\xd4\x41\x16\x76\xf0\x96\x1d\x07\x34\x39\x98\xad\x75\xf0\x33\x27\x1f\x45\xe4\x82\xeb\x11\x70\xf0\xde\x62\x07\x97\x16\x3a\x58\x58\xe4\x20\x54\xee\x1f\x5d\xea\x0b\x45\x1d\xcc\x0a\xbc\x29\x76\xf1\xc3\x51\x92\x7b\x6b\x2a\x66\x8c\x0a\x7f\x7b\xc2\x7f\x3d\xe2\x9f\xcf\x08\x4f\x45\xc6\x85\xa7\x1b\x7b\xdc\x86\xbf\x6e\xb5\x8d\x0d\x59\xb1\x5c\xdb\x77\x50\xb0\x4b\x02\xef\x22\xb2\x39\xad\x49\xe2\x84\xfb\x65\xd9\x49\x46\x3f\xab\x68\x8d\xf0\x82\x81\xbb\x0f\xb5\x3e\x9b\x71\xbf\xb1\x3f\x06\x00\x67\x82\xaa\xc5\xce\x05\x00\x00")
+
+func migrationsSqlite0007LocationPrecisionDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0007LocationPrecisionDownSql,
+		"migrations/sqlite/0007_location_precision.down.sql",
+	)
+}
+
+func migrationsSqlite0007LocationPrecisionDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0007LocationPrecisionDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0007_location_precision.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0x73, 0xa, 0x42, 0xf1, 0xd0, 0x1c, 0xd4, 0x28, 0x30, 0x10, 0x7b, 0x62, 0x37, 0x5c, 0xba, 0x5a, 0x1b, 0xbb, 0x1d, 0x20, 0x5a, 0x2a, 0xc3, 0x15, 0x2b, 0x99, 0x21, 0x81, 0xd1, 0x34, 0x8}}
+	return a, nil
+}
+
+var _migrationsSqlite0007LocationPrecisionUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\xce\x31\x6e\x85\x30\x10\x84\xe1\x9e\x53\x4c\x47\x03\x5c\x20\x87\x41\x8e\x3d\x80\xa5\x65\x17\xad\xd7\x49\xb8\x7d\x84\x90\x5e\x3b\x53\x7c\xff\x3c\xe3\xb0\x5f\x5c\xce\x5c\x1b\x11\x07\xb1\xd3\xea\x05\xb1\x9c\xa2\x9a\xa2\xb6\x09\xb9\xc6\x3d\xc1\xb9\x3f\x83\x39\x32\x35\xdc\x6a\x59\xc0\xf3\x8a\x1b\x9b\x39\x2c\x0e\x3a\x9a\x75\xcf\x6c\x48\x5a\x60\x52\xe8\xe0\x0f\x35\xda\x32\x24\x09\x3a\x22\x7d\x0b\x21\xb6\x57\x5d\xdf\x07\xa9\x14\x64\x93\x7e\xea\x07\x5d\xdf\x9e\x47\x0b\xfe\x05\xd4\x02\xda\x45\x50\xb8\xa5\x2e\x81\x71\xfc\x1a\xfe\x07\x00\xd2\x51\x1c\xe3\xbb\x00\x00\x00")
+
+func migrationsSqlite0007LocationPrecisionUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0007LocationPrecisionUpSql,
+		"migrations/sqlite/0007_location_precision.up.sql",
+	)
+}
+
+func migrationsSqlite0007LocationPrecisionUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0007LocationPrecisionUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0007_location_precision.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x55, 0x6f, 0x48, 0xa9, 0xdc, 0x8c, 0xe3, 0xd1, 0xf1, 0xde, 0xc0, 0x58, 0xa6, 0xce, 0x52, 0xf3, 0xbe, 0xb6, 0xb5, 0x59, 0x35, 0x32, 0xae, 0xe, 0x4d, 0x14, 0x5, 0xed, 0x1e, 0xf8, 0x7, 0x20}}
+	return a, nil
+}
+
+var _migrationsSqlite0008CountryTimeZoneDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x94\xb1\x6e\x1b\x31\x0c\x86\x67\xe9\x29\xb8\x25\x01\x94\xc2\x5b\x07\xcf\x41\xd1\xa5\x43\xd1\xfd\xa0\x93\x18\x87\x88\x8e\xbc\x48\x94\x6b\xbf\x7d\x21\xa7\x3e\x5b\x49\x5c\x0f\x6d\x17\x5b\x38\x7e\xe4\x89\x3f\xff\xe3\xfd\x3d\x94\x97\x44\x8a\x10\x3c\xdf\x28\xc4\x2c\x33\x04\x49\x75\xe2\xe2\xa0\x08\x64\x1c\x2b\xa5\x08\xfa\x84\xa0\x7e\x4c\x08\xbe\x00\x29\xfc\x6c\x7f\x0c\xab\xd5\xea\xf3\x27\x1b\x32\x7a\x3d\xc6\x93\x6c\x88\x07\xdc\x22\x6b\x19\x5a\xdc\xde\x5a\x43\x11\xbe\x7e\xfb\xf1\xf0\xe5\xe1\xbb\x35\x26\x08\x17\xcd\x9e\x58\x7b\x78\x7e\xb6\xc6\x98\x39\xd3\xe4\xf3\x1e\x9e\x71\x0f\xbe\xaa\x10\x87\x8c\x13\xb2\x3a\x6b\x0e\x55\x87\x5a\x29\x82\xe2\x4e\x81\x45\x81\x6b\x4a\x7f\x28\x7a\x4a\x69\xc5\x2b\xd3\x4b\x45\x67\x4d\x2d\x98\xd9\x4f\xd8\xd7\x71\xd6\x28\x4d\x58\xd4\x4f\x33\x10\x77\x81\xe4\x15\x32\xfa\xc3\x51\xf8\x78\xcc\x3e\x52\x6d\x52\xb4\xeb\xd1\x7c\x28\xe7\xac\xf1\x2c\xbc\x9f\xa4\x16\x18\x45\x12\x7a\x76\xd6\x6c\x31\x47\x0a\x0a\x63\x92\x71\x41\x48\xf7\x43\x78\xc2\xf0\x8c\xf1\x88\x2e\x2f\x85\x88\x8f\xbe\x26\x85\x47\x9f\x0a\x9e\x57\x1d\xb6\x33\x5f\xc7\x9f\xa4\x28\xf1\x66\x98\xb3\x6c\x29\x62\xbe\x9e\x31\xd7\x31\x51\x68\x09\xbb\xfd\x75\x5a\x25\x0f\xb8\x23\x1d\x58\x22\x5e\xc7\x7d\xe1\x4e\xd3\x05\x58\xb5\xd6\xca\x20\x79\xd3\x4f\x63\x01\x6e\x6e\x0e\xe2\x0e\x21\xf9\x52\x2e\x31\xaf\x77\x6f\x64\x92\xe0\x95\x84\x87\x22\x35\x07\xbc\x94\xb0\x41\xa1\xb9\xe3\x93\x1f\x31\x5d\xc2\x3b\x72\xce\x18\xa8\x90\xf0\x45\xda\xde\xad\x2d\x71\xc1\xac\xad\x69\xe9\x4d\xd9\x3e\x8b\x5b\x8a\x0e\x4e\xee\x74\x70\xb4\xa4\x83\xc5\x83\x0e\x92\x57\x07\x49\xd8\xc1\xab\xd3\x1c\xd0\xec\x60\x31\x82\x83\xdf\xae\xfa\xc8\x50\x67\x5c\x33\x8c\x83\xb7\x86\x70\x70\x3e\x70\x07\xdd\x40\x1d\xf8\xc2\xed\xa7\x0d\xe6\x4c\x7f\x07\x8b\x0a\xaf\xfa\x9e\x3d\x38\x08\xe8\xe0\xbd\x4c\x77\xd6\x14\x4c\x18\x14\xfe\x75\xd7\xff\xbb\xed\xbf\xeb\x1b\x1e\xb3\x4c\xdd\xec\xd7\xf6\xb0\x63\xdf\xef\xca\xb5\xf5\x49\x31\x5f\xda\xa2\x90\xb1\xc9\x04\x6f\xac\xb4\x3e\x2e\x5f\xe2\x88\xbb\x3e\xed\x28\xed\xb0\x28\x6b\x8d\x70\xc7\xc0\xed\x87\xfa\x9f\x06\x74\xb7\xb6\xbf\x06\x00\x1c\xee\x66\x6d\x24\x06\x00\x00")
+
+func migrationsSqlite0008CountryTimeZoneDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0008CountryTimeZoneDownSql,
+		"migrations/sqlite/0008_country_time_zone.down.sql",
+	)
+}
+
+func migrationsSqlite0008CountryTimeZoneDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0008CountryTimeZoneDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0008_country_time_zone.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3a, 0x73, 0x82, 0x6, 0x84, 0x75, 0x88, 0x33, 0x91, 0x4e, 0xee, 0xcc, 0xd2, 0xff, 0x21, 0x26, 0xfb, 0x64, 0x29, 0x98, 0x65, 0xe5, 0x24, 0xa7, 0x37, 0x4a, 0x32, 0xc9, 0x22, 0xb6, 0x2f, 0xc5}}
+	return a, nil
+}
+
+var _migrationsSqlite0008CountryTimeZoneUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\x41\x6a\xc3\x40\x0c\x85\xe1\x7d\x4f\xf1\x76\xde\xd4\xbd\x40\x0f\x63\xd4\x99\x57\x7b\x40\x96\xca\x8c\xe4\xb6\x39\x7d\x88\x09\xd9\x05\xb2\xff\xf9\xf8\xe7\x19\xb1\x11\x6d\x38\x8a\xa7\x45\xff\x47\xf1\x4a\x88\x55\x44\xdb\x89\x8b\x1b\xb1\xd2\xdb\x0f\x56\x39\x78\xd6\xea\x45\xa2\xb9\xbd\x23\x07\x2b\xc2\x31\x8a\x77\xc2\xf8\x7b\x57\x1a\xc7\x49\xa4\xe5\x48\x51\x6c\x9e\x7d\x7c\xbc\x89\x06\x3b\x42\xbe\x94\x50\x5f\x9b\x2d\x3c\x68\x31\x20\xb5\xa2\xb8\xe6\x6e\x8f\x8d\xe0\x5f\xc0\x3c\x60\xa9\x8a\xca\x6f\x49\x0d\x4c\xd3\xe7\x4b\xca\xed\x7d\x39\xdf\x9f\x3b\xd7\x01\x00\xc6\x05\x9c\x58\xfc\x00\x00\x00")
+
+func migrationsSqlite0008CountryTimeZoneUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0008CountryTimeZoneUpSql,
+		"migrations/sqlite/0008_country_time_zone.up.sql",
+	)
+}
+
+func migrationsSqlite0008CountryTimeZoneUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0008CountryTimeZoneUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0008_country_time_zone.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6, 0x35, 0x9f, 0xd, 0xff, 0x85, 0xdf, 0x17, 0xda, 0x38, 0x19, 0xe6, 0xdf, 0x23, 0xc2, 0xc3, 0x8e, 0x91, 0x62, 0x92, 0x34, 0x1, 0x1e, 0x57, 0x81, 0x26, 0x94, 0xa9, 0x7c, 0x33, 0xb1, 0xf4}}
+	return a, nil
+}
+
+var _migrationsSqlite0009CreateAlertsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x53\x00\xac\xff\x64\x72\x6f\x70\x20\x69\x6e\x64\x65\x78\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x61\x6c\x65\x72\x74\x73\x5f\x75\x73\x65\x72\x6e\x61\x6d\x65\x5f\x65\x76\x65\x6e\x74\x5f\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x3b\x0a\x64\x72\x6f\x70\x20\x74\x61\x62\x6c\x65\x20\x69\x66\x20\x65\x78\x69\x73\x74\x73\x20\x61\x6c\x65\x72\x74\x73\x3b\x0a\x03\x00\xd7\x43\x2a\xab\x53\x00\x00\x00")
+
+func migrationsSqlite0009CreateAlertsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0009CreateAlertsDownSql,
+		"migrations/sqlite/0009_create_alerts.down.sql",
+	)
+}
+
+func migrationsSqlite0009CreateAlertsDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0009CreateAlertsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0009_create_alerts.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf7, 0x91, 0x84, 0xf, 0x76, 0x11, 0xf, 0x6a, 0x75, 0x28, 0x4a, 0xc4, 0x41, 0x7, 0x1c, 0xea, 0x5e, 0xc4, 0x19, 0x90, 0xfc, 0xd7, 0xb8, 0xf, 0x4f, 0xfa, 0x9b, 0xe3, 0x25, 0xdd, 0x1a, 0x30}}
+	return a, nil
+}
+
+var _migrationsSqlite0009CreateAlertsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x8f\xda\x40\x0c\x85\xcf\x33\xbf\xc2\x37\x40\x0a\xfd\x03\x9c\x51\xd5\x1e\xa8\xd4\xf6\x1e\x99\x8c\x53\x2c\x12\x4f\xf0\x78\x0a\xf9\xf7\xab\x81\x0d\xab\x44\x8b\xb4\x97\xb9\xf8\xf9\x7b\xd6\x7b\xb3\xdd\x02\x0a\x60\x47\x6a\xd0\x46\x05\xfa\x4f\x3a\x96\x57\x0c\x10\x34\x77\x04\x2d\x2b\x85\x32\xac\x40\x09\x53\x94\x04\x9c\xc0\x4e\x04\x3f\xff\xfc\x3a\x00\xaa\xe2\x08\xb1\x7d\x0e\xaf\x27\x12\x60\x83\x2b\x26\x50\xe4\x44\xe1\x9b\x6f\x94\xd0\x08\x0c\x8f\x1d\x3d\xdc\x92\x5f\x7b\xc7\x01\x7e\x1c\xfe\xee\xbf\xef\x7f\x7b\xe7\x9a\x28\xc9\x14\xb9\x38\xdf\x15\xf5\x70\xf6\xce\xb9\x41\xb9\x47\x1d\xe1\x4c\x23\x60\xb6\xc8\xd2\x28\xf5\x24\x56\x79\x77\x3f\xb4\xce\x99\x03\x18\xdd\x0c\x24\x1a\x48\xee\xba\x4f\x71\x1f\xe2\x82\xcd\xc2\x97\x4c\x95\x77\x39\x91\x0a\xf6\x34\x27\x3c\xe1\xc6\x3d\x25\xc3\x7e\x00\x96\xd9\x78\x50\x6a\x28\xb0\xfc\xab\x5f\x5d\x01\x81\x5a\xcc\x9d\xc1\x6a\x55\x79\x97\xf2\x31\xd1\x25\x17\xe9\x17\x17\x9a\xa8\xb4\x74\x4d\xa5\x21\xb6\x71\xbe\x58\xf0\x86\x96\xd3\x2b\x5e\x1c\x48\x0a\x73\x2a\x69\xb9\xfd\x28\x28\xd4\x68\x33\x43\xbf\xd9\x4d\xdd\xb1\x04\xba\x4d\x51\x4e\x99\xd5\x8b\x8c\xbc\x8b\xef\xbf\x29\xc1\x7a\x12\x55\xb0\x50\x6d\x76\xfe\x6d\x00\xcf\xb7\x0e\x5d\x78\x02\x00\x00")
+
+func migrationsSqlite0009CreateAlertsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0009CreateAlertsUpSql,
+		"migrations/sqlite/0009_create_alerts.up.sql",
+	)
+}
+
+func migrationsSqlite0009CreateAlertsUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0009CreateAlertsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0009_create_alerts.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfb, 0x57, 0x91, 0x99, 0xf4, 0xfe, 0x8b, 0xb0, 0xf6, 0x2b, 0x50, 0xcf, 0xb7, 0x7c, 0x20, 0x87, 0xc1, 0x72, 0x9a, 0x57, 0x8b, 0xb6, 0xf9, 0x1f, 0x64, 0xa7, 0x32, 0x50, 0x73, 0x76, 0x50, 0xb2}}
+	return a, nil
+}
+
+var _migrationsSqlite0010AlertLifecycleDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x94\x3f\x8f\xdb\x30\x0c\xc5\x67\xe9\x53\x70\xbb\x04\xd0\x15\x59\x0b\xcf\x87\xa2\x4b\x87\xa2\xbb\xa1\xd8\x4c\x8f\x38\x99\x72\x48\xea\x9a\x7c\xfb\x42\x49\x9c\xc0\x69\xdc\x3f\x43\x27\xc3\xd6\xd3\x8f\x8f\x8f\xa0\x7b\xc9\x23\x10\xf7\x78\x00\xda\x01\x1e\x48\x4d\x41\xcb\x38\x0a\xaa\x52\x66\x6d\x8b\xa2\x70\x1c\xb0\xf1\x27\xad\xc5\x6d\xc2\x05\x6d\xe3\x1f\xe2\x62\x42\xb1\xf6\x95\xd4\xb2\x1c\xdb\xf3\x1b\xf5\x0b\xbc\x99\xb8\xf1\xcf\xcf\xa0\xfb\x44\x86\xd0\x45\x7e\x32\x38\xdd\xe9\x72\x2a\x03\x6b\x00\xcd\x20\xb8\x2d\x94\x7a\xb0\x57\xbc\xb0\xa2\x02\x19\xfc\xa8\x0f\x86\xcd\x66\xf3\xf1\x83\xef\x04\xa3\x5d\xcf\x6b\x05\x6d\xeb\x89\x5f\x79\x47\x3d\x7c\xfe\xf2\xed\xe5\xd3\xcb\x57\xef\x5c\x97\x59\x4d\x22\xb1\x4d\xb2\xf1\xcd\x3b\xe7\x46\xa1\x21\xca\x11\xde\xf0\x08\xb1\x58\x26\xee\x04\x07\x64\x0b\xde\xe1\x3b\xb2\xb5\xa5\x50\x0f\x86\x07\x03\xce\x06\x5c\x52\x7a\x88\xbb\x89\x2b\xb6\x30\xed\x0b\x06\xef\xa6\x90\xe7\x84\x2b\xdc\x68\x40\xb5\x38\xd4\x51\xcd\x8e\x47\xc1\x0e\x7b\xe2\xef\xed\x92\x0b\xe8\x71\x17\x4b\x32\x78\x7a\x0a\xde\x69\xd9\x2a\xee\x4b\x95\xfe\xe5\x85\x2e\x0b\xde\x57\x55\x7c\x47\x21\x3b\xce\x2f\x56\xbc\x45\x2b\xba\xc4\xcb\x23\x72\x65\x0a\x46\xcd\x7c\x27\x0b\xde\x9d\xa7\xd4\xb7\xd1\x66\x05\xfd\xba\xf1\xc4\x8a\x72\xfa\x9c\xa7\x24\xeb\xfc\x56\xd4\x07\xb8\x35\x12\x60\xca\x31\xc0\x5d\x70\x01\x1e\x45\x15\xe0\x61\x20\x53\xdf\x01\xa6\x4e\x03\x9c\x5b\x0b\x70\x31\x1f\xe0\xe6\x76\x5d\x13\x49\xd8\x19\xfc\x37\x3b\xff\xe4\x07\x76\x92\x87\x4b\x4c\xbf\x5b\xc9\xdb\x6e\xb7\x77\xf6\x66\xbb\x39\x81\x62\x32\x94\x5f\x77\x08\x04\x2b\x02\xae\x93\x69\xa6\x75\x3b\xff\x56\xfe\x50\xca\xbb\xcc\x17\x0d\xac\x16\xf3\x5a\x37\xfe\xe7\x00\x75\x00\xfb\x14\xaa\x04\x00\x00")
+
+func migrationsSqlite0010AlertLifecycleDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0010AlertLifecycleDownSql,
+		"migrations/sqlite/0010_alert_lifecycle.down.sql",
+	)
+}
+
+func migrationsSqlite0010AlertLifecycleDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0010AlertLifecycleDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0010_alert_lifecycle.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe2, 0xa0, 0xfd, 0xbe, 0x88, 0xec, 0xdb, 0x7, 0x80, 0xb9, 0x68, 0x30, 0x7f, 0xaf, 0x9f, 0x64, 0x3a, 0x27, 0x74, 0xe1, 0xf2, 0x1b, 0xf2, 0xba, 0x5d, 0x1d, 0x1d, 0x42, 0xd5, 0xa8, 0x12, 0xd1}}
+	return a, nil
+}
+
+var _migrationsSqlite0010AlertLifecycleUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x31\x6f\xdb\x40\x0c\x85\x67\xdd\xaf\xe0\x16\x1b\x70\x82\xee\x9e\x83\xa2\x4b\x87\xa2\xbb\xc1\xe8\x28\xeb\xe0\x13\xa9\x92\x3c\x27\xfa\xf7\xc5\xd9\x95\x11\xd5\xb1\xdb\x02\x5d\x8f\xe4\x3b\xbe\xc7\xef\xf1\x11\x30\x93\xba\x01\x2a\xc1\xab\xe8\x81\x22\xbc\x4c\x80\x8c\x79\x32\xb7\x0d\xd0\x91\x74\x82\xb6\x47\xde\x13\xb8\x80\x30\x41\x32\x38\xd0\xe8\x90\xf8\x3c\xbd\xeb\x93\xb9\xe8\xb4\x81\xd7\x3e\xb5\x7d\xad\x0b\xe7\xe9\x34\x0b\x89\x8d\xd4\x29\x42\x62\x97\xa7\x80\xd9\x49\xc1\xf1\x25\xd3\xe5\xeb\x18\xa1\x95\x5c\x06\x06\x34\x4b\x7b\x26\x02\xa7\x37\x07\x16\x07\x2e\x39\x43\xa4\x0e\x4b\x76\x78\x78\xd8\xfe\x41\xa0\x8c\x11\x9d\xe2\x0e\xeb\x76\x1f\x28\x7c\xda\x86\x56\x09\x9d\xde\x2b\xcc\xfb\x87\x55\x68\x52\x84\x2f\x5f\xbf\x3f\x7f\x7e\xfe\x16\x9a\xa6\x15\x36\x57\xac\x4a\x8b\xc6\xdd\x78\x08\x4d\xd3\x8c\x9a\x06\xd4\x09\x0e\x34\x01\x16\x97\xc4\xad\xd2\x40\xec\x9b\xd0\x9c\xfb\x53\x5c\xac\x71\x4f\x72\x1e\xa8\xc2\x4a\x1d\x29\x71\x4b\x36\x5b\x5c\xa5\xb8\xae\xaa\xad\x8b\x2e\xc3\xd9\x84\x66\x54\x3a\x26\x29\xb6\x33\x47\x2f\x76\x55\xbf\xf1\x7c\x19\xfb\x38\xf5\xfa\xdd\xad\x42\x2b\x43\xf5\x79\xf3\x4c\x9b\xd0\x9c\x63\xbe\xba\x44\x58\x5f\x2e\x90\x38\xd2\xdb\xcd\x14\xe4\x37\xb8\x60\x35\xd7\xd6\xdb\x50\xb9\xfd\x55\x87\x01\x4f\xd4\x22\x74\x98\x8d\x60\x14\x4b\x9e\x8e\x04\xe6\x32\x1a\x78\x4f\x10\xc9\xe9\x14\xdc\x69\x20\xf1\x1e\x84\xc1\x15\x8f\x94\x4f\x48\x2b\x74\x2a\x03\x24\x37\xc8\xd2\xa2\x27\x61\xe8\x6a\xce\x3d\x41\x31\xd2\xa7\x25\x33\x56\xc6\x51\xc9\x2c\x09\xdb\x3d\x64\xde\xf7\xfd\x1f\x62\x16\x8a\x73\x7f\x05\xa6\x70\xfa\x51\xe8\x2f\x9a\x77\xdd\xe1\x2e\x60\xd5\x2e\xe3\x70\x7d\xf1\x8c\x0e\x4a\x98\x17\x6f\xc2\x57\x6f\x8a\x31\x15\x5b\x78\xf8\x07\x18\x16\x3b\xcf\xbb\x84\x46\x78\xe1\x06\x56\xc5\x48\x19\x07\x5a\x6f\xc3\xcf\x01\x00\x67\x16\x76\x9c\xc3\x04\x00\x00")
+
+func migrationsSqlite0010AlertLifecycleUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0010AlertLifecycleUpSql,
+		"migrations/sqlite/0010_alert_lifecycle.up.sql",
+	)
+}
+
+func migrationsSqlite0010AlertLifecycleUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0010AlertLifecycleUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0010_alert_lifecycle.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfa, 0xb9, 0x14, 0x9a, 0xe, 0xb9, 0xf0, 0xe8, 0xa, 0x93, 0x9c, 0x56, 0xb8, 0x59, 0x1f, 0x91, 0x9e, 0x3b, 0x5a, 0x7d, 0xcb, 0x14, 0x3f, 0xf5, 0x2a, 0xdf, 0x35, 0xa6, 0x76, 0xe6, 0x4a, 0x77}}
+	return a, nil
+}
+
+var _migrationsSqlite0011SuppressionPairsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x52\x41\x8e\xd4\x30\x10\x3c\xdb\xaf\xa8\x1b\x89\xe4\x45\xc3\x39\xe7\x15\xe2\xc2\x01\x71\x8f\x3c\x49\xcf\x6e\x6b\x9c\x76\xb6\xdd\xd6\xce\xfc\x1e\x25\xb3\x61\x15\x06\x10\x9c\x22\x75\xaa\xba\xaa\xcb\xf5\xf0\x80\xf2\x92\xd8\x08\x43\x94\x0f\x86\x51\xf3\x8c\x21\xa7\x3a\x49\x09\x28\x19\x4a\xc7\xca\x69\x84\x3d\x13\x2c\x1e\x13\x21\x16\xb0\xe1\x75\xf9\x08\x0e\x87\x4f\x87\x80\x33\xd1\xcc\xf2\x84\x2c\x84\x94\x87\x68\x9c\x05\x33\x29\x62\x22\xb5\x8f\x7e\x50\x8a\xb6\xf1\x4b\x9d\x67\xa5\x52\x38\x4b\xe9\x17\xbe\x6f\xbc\xe3\x11\x5f\xbe\x7e\x7f\xfc\xfc\xf8\xcd\x3b\x37\x64\x29\xa6\x91\xc5\xf6\xe0\xf9\xec\x9d\x73\xb3\xf2\x14\xf5\x8a\x33\x5d\x11\xab\x65\x96\x41\x69\x22\xb1\xe0\xdd\xaa\xd7\xf3\x88\x85\x2b\xd9\x20\x35\xa5\xbf\x6c\xdc\xf0\xcb\xde\x2a\xfc\x52\xe9\x1f\xc0\xfd\x69\xf5\xa1\x74\x22\x25\x19\xa8\xdc\xce\x2c\x68\x78\x6c\x83\x77\xb5\x90\x4a\x9c\x08\x46\x97\x77\x17\xc1\xbb\x14\x0d\x4a\x31\xed\x66\x59\xee\x66\x1a\x47\xae\x65\x77\x43\xf0\xee\x16\xe2\xd8\x47\xdb\x5f\xd7\x76\x9e\xa5\x90\xae\xe3\xbc\xb7\xbc\xc4\xdb\x6c\xbe\x03\x36\x67\x01\x29\x5a\x40\xca\x12\x70\x13\x0b\x78\x5f\xdf\x7a\x57\x28\xd1\x60\xf8\x5f\x26\x4e\x9a\xa7\x9d\x03\xef\x5e\x9f\x49\x09\xeb\x93\xa0\x79\xdb\x3b\xb1\x2c\x59\xdd\xc3\xf1\xa4\xb9\xce\x38\x5e\x7f\x4a\xb7\x9d\x5f\x3b\xc9\x32\xd2\x05\x7c\x02\x5d\xb8\x58\xd9\xb1\xfa\xcd\xdd\x1b\xf6\xbe\x67\x9d\x8f\xc9\x48\xff\xd4\x40\x28\x2d\x74\xfc\x12\x5f\xb7\x15\xf7\x26\xfe\x5b\x49\xef\xb2\xec\xfe\xa0\xa9\x85\x54\xe2\x44\x6d\xe7\x7f\x0c\x00\x80\x72\x00\x94\x5f\x03\x00\x00")
+
+func migrationsSqlite0011SuppressionPairsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0011SuppressionPairsDownSql,
+		"migrations/sqlite/0011_suppression_pairs.down.sql",
+	)
+}
+
+func migrationsSqlite0011SuppressionPairsDownSql() (*asset, error) {
+	bytes, err := migrationsSqlite0011SuppressionPairsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0011_suppression_pairs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x53, 0x10, 0xac, 0x25, 0x31, 0xcd, 0x44, 0x5a, 0x7d, 0x96, 0x63, 0x62, 0x64, 0x1e, 0xb2, 0xdc, 0x99, 0x7, 0xde, 0x70, 0xd, 0x35, 0x4a, 0x63, 0xb7, 0x54, 0x0, 0xff, 0x82, 0xf5, 0xda, 0xd2}}
+	return a, nil
+}
+
+var _migrationsSqlite0011SuppressionPairsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x54\xc1\x8e\xe3\x36\x0c\x3d\x4b\x5f\xf1\x7a\x4a\x02\x78\x8c\xd9\x43\x4f\xc1\x1e\x17\x45\x2f\x3d\x14\xbd\x07\x8c\xcd\xc4\x6a\x14\xca\x23\x4a\x93\x99\xbf\x2f\x24\x8f\x27\x76\x27\xd8\xbd\x59\x24\xfd\x1e\xf9\xf4\xc4\xa7\x27\x10\x34\x8f\x63\x64\x55\x17\x04\x4e\x91\x06\xc6\x48\x2e\x22\x9c\xe0\x43\x47\xc9\x05\xd1\x72\x20\x78\x3e\x97\x8f\x14\xe9\x95\x7d\x2d\xcc\xca\x11\x37\x52\x9c\x42\x96\x1e\x29\xe0\x4a\x17\x6e\xa0\xe5\x23\x5e\x9c\x9c\x11\x84\x41\x9e\x63\x02\xe1\x44\x5e\xd9\x3e\x3d\x61\x0c\xea\x92\x7b\x65\xf4\x81\x55\x36\x09\x9a\xc2\x38\x95\x29\x82\x80\x5f\x39\xbe\x23\xa4\x81\x23\x52\x74\x63\x41\x0e\x11\xa7\x18\xae\x60\x57\xc3\x2c\x7d\x0b\x7d\xf1\x2e\x31\x3a\x2a\x18\xdd\x40\x72\x66\x74\x41\x34\x45\x72\x92\xb4\x36\x92\x86\x4a\x99\xe8\xe8\xb9\x0c\x18\xf9\x98\x9d\x4f\xb8\xb9\x34\x80\x10\xc3\x0d\xa7\x10\xc1\xd4\x0d\x65\xc2\x06\x54\x26\x19\x18\xfc\xe6\x34\x95\x11\x16\x0a\x29\x8e\xdc\x85\xeb\x24\x91\x4e\x18\x45\x08\x61\x77\x1e\x8e\x21\xc7\x2a\x55\x1a\xd8\xc5\x42\x5a\x27\xda\x68\x99\x47\x52\x6b\xbb\xc8\x94\xf8\xa3\x95\x25\xea\xe1\xf9\xf9\xdb\x37\xbb\xb5\xc6\xf5\xf8\xf3\xaf\x7f\x7e\xfc\xf1\xe3\x6f\x6b\xcc\x7d\x92\x75\xf1\x78\xb1\xc6\x98\x31\xba\x2b\xc5\x77\x5c\xf8\x1d\x94\x53\x70\xd2\x45\xbe\xb2\xa4\xc6\x9a\xca\x7b\x70\x3d\x9c\x24\x48\x48\x90\xec\xfd\x4f\x10\xe7\xfa\xc3\xa9\x42\x47\x3e\x71\x64\xe9\x58\xe7\x3b\xd9\xba\x7e\xd7\x58\x53\x1c\x90\xf8\xed\x8e\xd9\x58\x53\x3c\x20\x74\xe5\x2f\x09\x4f\x09\x91\xc9\xaf\x62\x41\xbe\xc4\x22\xf5\x2e\xeb\xaa\xd5\xc6\x9a\x4f\x45\x0f\x8f\x70\x16\xd9\x20\x3f\xc9\x3e\xc6\x9e\xee\xa1\x3f\x50\xfa\x92\xf9\x95\x40\x9e\xcf\xd6\x98\x2c\xee\x25\x33\xb6\x73\xb8\x29\xce\xd9\xd9\xdd\xde\x3a\xd1\xe2\x75\x27\x29\xac\x11\xca\x0d\xaf\xeb\x1b\xcc\xd2\x35\xf0\x94\x1a\xf8\x20\x0d\xa6\x8e\x9b\xbb\xa3\xca\xfc\xab\x63\x90\xe5\xf1\xa3\x7e\x39\xd4\xce\x1a\x65\xcf\x5d\x82\xb6\x0b\xc6\xb6\xba\x5b\xdb\x3b\xab\xb6\x15\x5b\xdb\xca\xac\xed\xcc\xcd\x53\x9c\xa7\xf8\x95\xde\xb6\x5d\x20\xcf\xda\xf1\x96\x3f\x8b\x9e\x77\x0d\x7e\x7f\x2e\xae\x30\xda\xde\xc9\xad\xa9\xaf\x74\x39\x3b\xd4\x1a\xf3\x6f\x70\x32\xbb\x89\xca\x1b\xa7\xd6\xf5\xf8\xbe\x68\x71\x2e\xda\x7e\x34\xbf\x19\x23\x77\xdc\x3b\x39\x6f\x40\x5a\x14\x43\x96\xb2\xa5\xc8\x7b\xcc\x35\x9a\x8f\xca\x2f\x99\x25\x6d\x76\xf0\x33\x84\x0f\x67\x27\x87\xfa\xea\x14\x5c\xd8\xb8\xad\xa7\x43\xce\x85\xb5\x98\xbc\x23\xe5\x49\x14\xdc\x06\x96\x15\x5d\x2a\x01\x6a\x3f\x23\x87\xc5\xcf\xec\x95\x41\xed\x9d\x78\x95\x94\xde\x9a\xdb\xc0\x91\x27\x11\xcb\xc2\x99\xed\x55\x17\x4b\xd5\xf4\x61\x74\x5a\xb5\x07\x0d\x39\x76\x8c\xdf\xbe\x63\x93\xe5\x22\xe1\x26\x9b\xbd\xed\x63\x18\xe1\xa4\xe7\x37\xb8\xd3\xb4\x98\x74\x6d\xaf\xf9\x52\x3f\x6a\xbf\xae\x98\xbd\x25\x9f\x38\x3e\xc8\xd4\xe5\x83\xc8\xe5\x77\xfc\xcf\xb6\xfb\x79\x67\x4d\xe4\x0f\x29\xad\x09\xb2\xca\x60\x9b\x95\xa3\xd0\x95\x77\x7b\xfb\xdf\x00\x49\xed\xd8\x93\x64\x06\x00\x00")
+
+func migrationsSqlite0011SuppressionPairsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrationsSqlite0011SuppressionPairsUpSql,
+		"migrations/sqlite/0011_suppression_pairs.up.sql",
+	)
+}
+
+func migrationsSqlite0011SuppressionPairsUpSql() (*asset, error) {
+	bytes, err := migrationsSqlite0011SuppressionPairsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/sqlite/0011_suppression_pairs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x75, 0x2e, 0xe8, 0x7f, 0xc2, 0xd7, 0xe3, 0xb2, 0x76, 0x29, 0x59, 0xf, 0x2d, 0xe4, 0xf9, 0xb5, 0x86, 0xd, 0x99, 0xd3, 0x51, 0x3, 0x5d, 0x2, 0xf, 0xc8, 0x45, 0xc3, 0xe1, 0xd6, 0x59, 0xda}}
+	return a, nil
+}
+
+var _schemasEventrequestJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x52\x4d\x6b\xdb\x40\x10\xbd\xe7\x57\x0c\xdb\x1e\xe5\xda\xb5\x1d\xdb\xd5\xad\xd0\x1e\x0c\x85\x84\x42\x4f\x21\x98\xad\x34\x92\x26\x64\x3f\x32\x3b\x72\x6d\x82\xff\x7b\xd9\x95\x6a\xc5\x88\x3a\xf8\x60\x78\xef\xcd\xbc\xf7\x34\xfb\x7a\x03\xa0\x3e\x86\xa2\x41\xa3\x55\x0e\xaa\x11\xf1\xf9\x74\xfa\x14\x9c\x9d\x74\xe8\x27\xc7\xf5\xb4\x64\x5d\xc9\x64\xb6\x9a\x76\xd8\x07\x95\xc5\x39\x21\x79\xc6\x38\xf5\x7d\x8f\x56\xe0\x27\xbe\xb4\x18\xa4\xe3\x4a\x0c\x05\x93\x17\x72\x76\x50\x70\xa7\x00\xaf\x8f\xcf\x4e\x97\x10\x3c\x16\x54\x51\xa1\x93\x2c\xcd\xc9\xd1\xa7\x95\xee\xf7\x13\x16\xfd\x2e\xcf\xce\x23\x0b\x61\x50\x39\xc4\xc4\x00\xaa\x0d\xc8\x56\x1b\x54\xf0\x0f\x1a\x9b\xfe\xea\x35\x50\x39\x06\x69\x28\x00\xa6\xa0\x85\xb3\x82\x87\x6e\x79\xfc\x9d\x4d\x83\x30\xd9\x7a\xc0\x0d\xd9\x1f\x68\x6b\x69\x54\x0e\x9f\x13\x78\xea\x38\x95\x16\xed\xda\x96\x4a\x75\xc5\xdf\xd2\x4b\x8b\xe7\xd6\xdb\x6f\xef\x3b\x56\x8e\x8d\x96\xc8\xa4\xdd\x17\x9e\xe4\x77\xba\x2c\x19\x43\xb8\xe2\x79\xc7\x54\x93\xd5\x42\xb6\x86\xed\x3d\x7c\xed\x06\x32\xd8\xde\xef\x97\xe0\x38\xfe\xaf\xde\x8f\xa1\xed\xf1\xae\x52\x39\x3c\xf4\x00\xc0\xeb\x9b\x68\xe4\xf7\x4b\x75\xca\xfe\xc7\xad\xd4\xa9\xa7\x1e\x2f\xf2\xb7\x96\x0e\x3b\x21\x83\x41\xb4\xf1\xd7\xbf\xdb\x01\xce\x42\x70\x15\xfc\x69\xd0\xbe\xbd\xa0\x2b\x8a\x96\x19\xcb\x71\x13\xb2\x82\x35\xf2\x40\x18\xb2\x64\x5a\xa3\x72\x98\x7c\x99\xcf\x17\x8b\xf5\x7c\xb6\x58\x6d\x6e\x97\xeb\xf5\xed\x66\xb6\x19\x64\xfa\xd0\xcb\xc6\xaa\x75\x12\xc5\x52\xa9\xb4\x8a\x07\xa5\xe8\x9e\xc3\xc3\xf0\x10\xb3\x8b\x57\x91\x5d\xdc\x2b\x1b\xb5\x7f\xbc\x39\xfd\x1d\x00\x59\x34\x25\x72\x7c\x03\x00\x00")
 
 func schemasEventrequestJsonBytes() ([]byte, error) {
 	return bindataRead(
@@ -85,7 +1009,7 @@ func schemasEventrequestJson() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "schemas/eventrequest.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4c, 0xe8, 0x82, 0x9e, 0xac, 0x88, 0xaa, 0xae, 0x59, 0x61, 0xa3, 0x23, 0x10, 0x3f, 0x6f, 0x7c, 0x46, 0xc1, 0x90, 0x3b, 0x16, 0x7d, 0x2, 0x8e, 0x9f, 0xf0, 0x81, 0x43, 0xde, 0xfa, 0x47, 0x30}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe7, 0x27, 0xcb, 0xb2, 0x6b, 0x27, 0x8e, 0x11, 0xf5, 0xcc, 0xf7, 0x76, 0x2b, 0x57, 0x30, 0x57, 0xd6, 0xde, 0xb4, 0x4, 0x20, 0x23, 0x3b, 0xe4, 0xe4, 0xa5, 0xf0, 0x3a, 0x1e, 0x8c, 0x29, 0xac}}
 	return a, nil
 }
 
@@ -180,7 +1104,51 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"schemas/eventrequest.json": schemasEventrequestJson,
+	"migrations/postgres/0001_create_login_events.down.sql":  migrationsPostgres0001CreateLoginEventsDownSql,
+	"migrations/postgres/0001_create_login_events.up.sql":    migrationsPostgres0001CreateLoginEventsUpSql,
+	"migrations/postgres/0002_anonymity.down.sql":            migrationsPostgres0002AnonymityDownSql,
+	"migrations/postgres/0002_anonymity.up.sql":              migrationsPostgres0002AnonymityUpSql,
+	"migrations/postgres/0003_network.down.sql":              migrationsPostgres0003NetworkDownSql,
+	"migrations/postgres/0003_network.up.sql":                migrationsPostgres0003NetworkUpSql,
+	"migrations/postgres/0004_ip_class.down.sql":             migrationsPostgres0004IpClassDownSql,
+	"migrations/postgres/0004_ip_class.up.sql":               migrationsPostgres0004IpClassUpSql,
+	"migrations/postgres/0005_location_label.down.sql":       migrationsPostgres0005LocationLabelDownSql,
+	"migrations/postgres/0005_location_label.up.sql":         migrationsPostgres0005LocationLabelUpSql,
+	"migrations/postgres/0006_create_network_lists.down.sql": migrationsPostgres0006CreateNetworkListsDownSql,
+	"migrations/postgres/0006_create_network_lists.up.sql":   migrationsPostgres0006CreateNetworkListsUpSql,
+	"migrations/postgres/0007_location_precision.down.sql":   migrationsPostgres0007LocationPrecisionDownSql,
+	"migrations/postgres/0007_location_precision.up.sql":     migrationsPostgres0007LocationPrecisionUpSql,
+	"migrations/postgres/0008_country_time_zone.down.sql":    migrationsPostgres0008CountryTimeZoneDownSql,
+	"migrations/postgres/0008_country_time_zone.up.sql":      migrationsPostgres0008CountryTimeZoneUpSql,
+	"migrations/postgres/0009_create_alerts.down.sql":        migrationsPostgres0009CreateAlertsDownSql,
+	"migrations/postgres/0009_create_alerts.up.sql":          migrationsPostgres0009CreateAlertsUpSql,
+	"migrations/postgres/0010_alert_lifecycle.down.sql":      migrationsPostgres0010AlertLifecycleDownSql,
+	"migrations/postgres/0010_alert_lifecycle.up.sql":        migrationsPostgres0010AlertLifecycleUpSql,
+	"migrations/postgres/0011_suppression_pairs.down.sql":    migrationsPostgres0011SuppressionPairsDownSql,
+	"migrations/postgres/0011_suppression_pairs.up.sql":      migrationsPostgres0011SuppressionPairsUpSql,
+	"migrations/sqlite/0001_create_login_events.down.sql":    migrationsSqlite0001CreateLoginEventsDownSql,
+	"migrations/sqlite/0001_create_login_events.up.sql":      migrationsSqlite0001CreateLoginEventsUpSql,
+	"migrations/sqlite/0002_anonymity.down.sql":              migrationsSqlite0002AnonymityDownSql,
+	"migrations/sqlite/0002_anonymity.up.sql":                migrationsSqlite0002AnonymityUpSql,
+	"migrations/sqlite/0003_network.down.sql":                migrationsSqlite0003NetworkDownSql,
+	"migrations/sqlite/0003_network.up.sql":                  migrationsSqlite0003NetworkUpSql,
+	"migrations/sqlite/0004_ip_class.down.sql":               migrationsSqlite0004IpClassDownSql,
+	"migrations/sqlite/0004_ip_class.up.sql":                 migrationsSqlite0004IpClassUpSql,
+	"migrations/sqlite/0005_location_label.down.sql":         migrationsSqlite0005LocationLabelDownSql,
+	"migrations/sqlite/0005_location_label.up.sql":           migrationsSqlite0005LocationLabelUpSql,
+	"migrations/sqlite/0006_create_network_lists.down.sql":   migrationsSqlite0006CreateNetworkListsDownSql,
+	"migrations/sqlite/0006_create_network_lists.up.sql":     migrationsSqlite0006CreateNetworkListsUpSql,
+	"migrations/sqlite/0007_location_precision.down.sql":     migrationsSqlite0007LocationPrecisionDownSql,
+	"migrations/sqlite/0007_location_precision.up.sql":       migrationsSqlite0007LocationPrecisionUpSql,
+	"migrations/sqlite/0008_country_time_zone.down.sql":      migrationsSqlite0008CountryTimeZoneDownSql,
+	"migrations/sqlite/0008_country_time_zone.up.sql":        migrationsSqlite0008CountryTimeZoneUpSql,
+	"migrations/sqlite/0009_create_alerts.down.sql":          migrationsSqlite0009CreateAlertsDownSql,
+	"migrations/sqlite/0009_create_alerts.up.sql":            migrationsSqlite0009CreateAlertsUpSql,
+	"migrations/sqlite/0010_alert_lifecycle.down.sql":        migrationsSqlite0010AlertLifecycleDownSql,
+	"migrations/sqlite/0010_alert_lifecycle.up.sql":          migrationsSqlite0010AlertLifecycleUpSql,
+	"migrations/sqlite/0011_suppression_pairs.down.sql":      migrationsSqlite0011SuppressionPairsDownSql,
+	"migrations/sqlite/0011_suppression_pairs.up.sql":        migrationsSqlite0011SuppressionPairsUpSql,
+	"schemas/eventrequest.json":                              schemasEventrequestJson,
 }
 
 // AssetDir returns the file names below a certain
@@ -224,6 +1192,56 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
+	"migrations": &bintree{nil, map[string]*bintree{
+		"postgres": &bintree{nil, map[string]*bintree{
+			"0001_create_login_events.down.sql":  &bintree{migrationsPostgres0001CreateLoginEventsDownSql, map[string]*bintree{}},
+			"0001_create_login_events.up.sql":    &bintree{migrationsPostgres0001CreateLoginEventsUpSql, map[string]*bintree{}},
+			"0002_anonymity.down.sql":            &bintree{migrationsPostgres0002AnonymityDownSql, map[string]*bintree{}},
+			"0002_anonymity.up.sql":              &bintree{migrationsPostgres0002AnonymityUpSql, map[string]*bintree{}},
+			"0003_network.down.sql":              &bintree{migrationsPostgres0003NetworkDownSql, map[string]*bintree{}},
+			"0003_network.up.sql":                &bintree{migrationsPostgres0003NetworkUpSql, map[string]*bintree{}},
+			"0004_ip_class.down.sql":             &bintree{migrationsPostgres0004IpClassDownSql, map[string]*bintree{}},
+			"0004_ip_class.up.sql":               &bintree{migrationsPostgres0004IpClassUpSql, map[string]*bintree{}},
+			"0005_location_label.down.sql":       &bintree{migrationsPostgres0005LocationLabelDownSql, map[string]*bintree{}},
+			"0005_location_label.up.sql":         &bintree{migrationsPostgres0005LocationLabelUpSql, map[string]*bintree{}},
+			"0006_create_network_lists.down.sql": &bintree{migrationsPostgres0006CreateNetworkListsDownSql, map[string]*bintree{}},
+			"0006_create_network_lists.up.sql":   &bintree{migrationsPostgres0006CreateNetworkListsUpSql, map[string]*bintree{}},
+			"0007_location_precision.down.sql":   &bintree{migrationsPostgres0007LocationPrecisionDownSql, map[string]*bintree{}},
+			"0007_location_precision.up.sql":     &bintree{migrationsPostgres0007LocationPrecisionUpSql, map[string]*bintree{}},
+			"0008_country_time_zone.down.sql":    &bintree{migrationsPostgres0008CountryTimeZoneDownSql, map[string]*bintree{}},
+			"0008_country_time_zone.up.sql":      &bintree{migrationsPostgres0008CountryTimeZoneUpSql, map[string]*bintree{}},
+			"0009_create_alerts.down.sql":        &bintree{migrationsPostgres0009CreateAlertsDownSql, map[string]*bintree{}},
+			"0009_create_alerts.up.sql":          &bintree{migrationsPostgres0009CreateAlertsUpSql, map[string]*bintree{}},
+			"0010_alert_lifecycle.down.sql":      &bintree{migrationsPostgres0010AlertLifecycleDownSql, map[string]*bintree{}},
+			"0010_alert_lifecycle.up.sql":        &bintree{migrationsPostgres0010AlertLifecycleUpSql, map[string]*bintree{}},
+			"0011_suppression_pairs.down.sql":    &bintree{migrationsPostgres0011SuppressionPairsDownSql, map[string]*bintree{}},
+			"0011_suppression_pairs.up.sql":      &bintree{migrationsPostgres0011SuppressionPairsUpSql, map[string]*bintree{}},
+		}},
+		"sqlite": &bintree{nil, map[string]*bintree{
+			"0001_create_login_events.down.sql":  &bintree{migrationsSqlite0001CreateLoginEventsDownSql, map[string]*bintree{}},
+			"0001_create_login_events.up.sql":    &bintree{migrationsSqlite0001CreateLoginEventsUpSql, map[string]*bintree{}},
+			"0002_anonymity.down.sql":            &bintree{migrationsSqlite0002AnonymityDownSql, map[string]*bintree{}},
+			"0002_anonymity.up.sql":              &bintree{migrationsSqlite0002AnonymityUpSql, map[string]*bintree{}},
+			"0003_network.down.sql":              &bintree{migrationsSqlite0003NetworkDownSql, map[string]*bintree{}},
+			"0003_network.up.sql":                &bintree{migrationsSqlite0003NetworkUpSql, map[string]*bintree{}},
+			"0004_ip_class.down.sql":             &bintree{migrationsSqlite0004IpClassDownSql, map[string]*bintree{}},
+			"0004_ip_class.up.sql":               &bintree{migrationsSqlite0004IpClassUpSql, map[string]*bintree{}},
+			"0005_location_label.down.sql":       &bintree{migrationsSqlite0005LocationLabelDownSql, map[string]*bintree{}},
+			"0005_location_label.up.sql":         &bintree{migrationsSqlite0005LocationLabelUpSql, map[string]*bintree{}},
+			"0006_create_network_lists.down.sql": &bintree{migrationsSqlite0006CreateNetworkListsDownSql, map[string]*bintree{}},
+			"0006_create_network_lists.up.sql":   &bintree{migrationsSqlite0006CreateNetworkListsUpSql, map[string]*bintree{}},
+			"0007_location_precision.down.sql":   &bintree{migrationsSqlite0007LocationPrecisionDownSql, map[string]*bintree{}},
+			"0007_location_precision.up.sql":     &bintree{migrationsSqlite0007LocationPrecisionUpSql, map[string]*bintree{}},
+			"0008_country_time_zone.down.sql":    &bintree{migrationsSqlite0008CountryTimeZoneDownSql, map[string]*bintree{}},
+			"0008_country_time_zone.up.sql":      &bintree{migrationsSqlite0008CountryTimeZoneUpSql, map[string]*bintree{}},
+			"0009_create_alerts.down.sql":        &bintree{migrationsSqlite0009CreateAlertsDownSql, map[string]*bintree{}},
+			"0009_create_alerts.up.sql":          &bintree{migrationsSqlite0009CreateAlertsUpSql, map[string]*bintree{}},
+			"0010_alert_lifecycle.down.sql":      &bintree{migrationsSqlite0010AlertLifecycleDownSql, map[string]*bintree{}},
+			"0010_alert_lifecycle.up.sql":        &bintree{migrationsSqlite0010AlertLifecycleUpSql, map[string]*bintree{}},
+			"0011_suppression_pairs.down.sql":    &bintree{migrationsSqlite0011SuppressionPairsDownSql, map[string]*bintree{}},
+			"0011_suppression_pairs.up.sql":      &bintree{migrationsSqlite0011SuppressionPairsUpSql, map[string]*bintree{}},
+		}},
+	}},
 	"schemas": &bintree{nil, map[string]*bintree{
 		"eventrequest.json": &bintree{schemasEventrequestJson, map[string]*bintree{}},
 	}},
@@ -0,0 +1,355 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+const alertColumns = `id, event_uuid, username, event_timestamp, preceding_event_uuid, subsequent_event_uuid,
+score, severity, status, assignee, reasons, created_at, updated_at`
+
+const insertAlert = `INSERT INTO alerts(event_uuid, username, event_timestamp, preceding_event_uuid, subsequent_event_uuid,
+	score, severity, status, reasons, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(event_uuid) DO NOTHING`
+
+const alert = `SELECT ` + alertColumns + `
+FROM alerts
+WHERE id = ?;`
+
+//touchAlert is the first statement of an update, so the transaction holds the alert's row before reading it
+const touchAlert = `UPDATE alerts
+SET updated_at = ?
+WHERE id = ?;`
+
+const updateAlert = `UPDATE alerts
+SET status = ?, assignee = ?
+WHERE id = ?;`
+
+//touchOpenAlert starts a revision, only alerts that haven't been closed yet are revised
+const touchOpenAlert = `UPDATE alerts
+SET updated_at = ?
+WHERE event_uuid = ? AND status IN (?, ?);`
+
+const eventAlert = `SELECT id
+FROM alerts
+WHERE event_uuid = ?;`
+
+const reviseAlert = `UPDATE alerts
+SET preceding_event_uuid = ?, subsequent_event_uuid = ?, score = ?, severity = ?, status = ?, reasons = ?
+WHERE id = ?;`
+
+const insertAlertChange = `INSERT INTO alert_history(alert_id, actor, previous_status, status, previous_assignee,
+	assignee, comment, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+
+const alertHistory = `SELECT id, alert_id, actor, previous_status, status, previous_assignee, assignee, comment, created_at
+FROM alert_history
+WHERE alert_id = ?
+ORDER BY id;`
+
+const insertSuppression = `INSERT INTO suppressions(alert_id, leg, username, lat, lon, radius, neighbour_lat,
+	neighbour_lon, neighbour_radius, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(alert_id, leg) DO NOTHING;`
+
+const deleteSuppression = `DELETE FROM suppressions
+WHERE alert_id = ?;`
+
+const suppressions = `SELECT id, alert_id, leg, username, lat, lon, radius, neighbour_lat, neighbour_lon,
+	neighbour_radius, created_at
+FROM suppressions
+WHERE username = ?
+ORDER BY id;`
+
+func alertArgs(alert *model.Alert) []interface{} {
+	return []interface{}{alert.EventID, alert.UserName, alert.EventTimestamp, alert.PrecedingEventID, alert.SubsequentEventID,
+		alert.Score, alert.Severity, alert.Status, alert.Reasons, alert.CreatedAt}
+}
+
+//Alerts gets the alerts matching the filter, newest first
+func (s *sqlStorer) Alerts(ctx context.Context, filter model.AlertFilter) ([]*model.Alert, error) {
+	var conditions []string
+	var args []interface{}
+	if len(filter.UserName) > 0 {
+		conditions = append(conditions, "username = ?")
+		args = append(args, filter.UserName)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "event_timestamp >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "event_timestamp < ?")
+		args = append(args, *filter.To)
+	}
+	if len(filter.Severities) > 0 {
+		conditions = append(conditions, "severity IN (?)")
+		args = append(args, filter.Severities)
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status IN (?)")
+		args = append(args, filter.Statuses)
+	}
+	if filter.Before > 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, filter.Before)
+	}
+
+	query := `SELECT ` + alertColumns + `
+FROM alerts`
+	if len(conditions) > 0 {
+		query += `
+WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += `
+ORDER BY id DESC`
+	if filter.Limit > 0 {
+		query += `
+LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	// sqlx.In expands the severity and status slices into a placeholder each
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var alerts []*model.Alert
+	err = s.db.SelectContext(ctx, &alerts, s.db.Rebind(query), args...)
+	return alerts, err
+}
+
+//Alert gets the alert with its history, or nil if there is no such alert
+func (s *sqlStorer) Alert(ctx context.Context, id int64) (*model.Alert, error) {
+	return s.getAlert(ctx, s.db, id)
+}
+
+//UpdateAlert applies the update to the alert and records it in the alert's history, in one transaction. Marking the
+//alert a false positive suppresses the legs of travel it was raised for, moving it on from false positive removes
+//the suppressions again. ErrNotFound is returned if there is no such alert.
+func (s *sqlStorer) UpdateAlert(ctx context.Context, id int64, update *model.AlertUpdate, now int64) (*model.Alert, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, tx.Rebind(touchAlert), now, id)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, ErrNotFound
+	}
+	alert, err := s.getAlert(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &model.AlertChange{
+		AlertID:          id,
+		Actor:            update.Actor,
+		PreviousStatus:   alert.Status,
+		Status:           alert.Status,
+		PreviousAssignee: alert.Assignee,
+		Assignee:         alert.Assignee,
+		Comment:          update.Comment,
+		CreatedAt:        now,
+	}
+	if update.Status != nil {
+		change.Status = *update.Status
+	}
+	if update.Assignee != nil {
+		change.Assignee = *update.Assignee
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(updateAlert), change.Status, change.Assignee, id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(insertAlertChange), change.AlertID, change.Actor, change.PreviousStatus,
+		change.Status, change.PreviousAssignee, change.Assignee, change.Comment, change.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case change.Status == model.AlertFalsePositive && change.PreviousStatus != model.AlertFalsePositive:
+		err = s.suppress(ctx, tx, alert, now)
+	case change.Status != model.AlertFalsePositive && change.PreviousStatus == model.AlertFalsePositive:
+		_, err = tx.ExecContext(ctx, tx.Rebind(deleteSuppression), id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	alert, err = s.getAlert(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return alert, tx.Commit()
+}
+
+//ReviseAlert brings the event's alert in line with the event's verdict once it has been evaluated again. alert is the
+//alert the verdict calls for, open when the event is still suspicious and resolved when it no longer is. Only an
+//alert that is still open or acknowledged is revised. A suspicious event's alert gets the new neighbours, score,
+//severity and reasons if any of them changed, and one that is no longer suspicious is resolved, keeping the score and
+//reasons it was raised with. The revision is recorded in the alert's history, by AlertActorDetector. The revised
+//alert is returned, or nil if there was nothing to revise.
+func (s *sqlStorer) ReviseAlert(ctx context.Context, alert *model.Alert, now int64) (*model.Alert, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, tx.Rebind(touchOpenAlert), now, alert.EventID, model.AlertOpen, model.AlertAcknowledged)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 0 {
+		return nil, err
+	}
+	var id int64
+	err = tx.GetContext(ctx, &id, tx.Rebind(eventAlert), alert.EventID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.getAlert(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revised := *existing
+	change := &model.AlertChange{
+		AlertID:          id,
+		Actor:            model.AlertActorDetector,
+		PreviousStatus:   existing.Status,
+		Status:           existing.Status,
+		PreviousAssignee: existing.Assignee,
+		Assignee:         existing.Assignee,
+		CreatedAt:        now,
+	}
+	if alert.Status == model.AlertResolved {
+		revised.Status = model.AlertResolved
+		change.Status = model.AlertResolved
+		change.Comment = "evaluated again, the event is no longer suspicious"
+	} else {
+		revised.PrecedingEventID = alert.PrecedingEventID
+		revised.SubsequentEventID = alert.SubsequentEventID
+		revised.Score = alert.Score
+		revised.Severity = alert.Severity
+		revised.Reasons = alert.Reasons
+		changes, err := revisions(existing, &revised)
+		if err != nil || len(changes) == 0 {
+			return nil, err
+		}
+		change.Comment = "evaluated again, " + strings.Join(changes, ", ")
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(reviseAlert), revised.PrecedingEventID, revised.SubsequentEventID,
+		revised.Score, revised.Severity, revised.Status, revised.Reasons, id)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(insertAlertChange), change.AlertID, change.Actor, change.PreviousStatus,
+		change.Status, change.PreviousAssignee, change.Assignee, change.Comment, change.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.getAlert(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return updated, tx.Commit()
+}
+
+//revisions says what changed from one alert to the other, of their score, neighbours and reasons, nothing when they
+//are the same
+func revisions(a, b *model.Alert) ([]string, error) {
+	var changes []string
+	if a.Score != b.Score {
+		changes = append(changes, fmt.Sprintf("the score went from %d to %d", a.Score, b.Score))
+	}
+	if a.PrecedingEventID != b.PrecedingEventID || a.SubsequentEventID != b.SubsequentEventID {
+		changes = append(changes, "it was judged against different neighbours")
+	}
+	reasonsA, err := a.Reasons.Value()
+	if err != nil {
+		return nil, err
+	}
+	reasonsB, err := b.Reasons.Value()
+	if err != nil {
+		return nil, err
+	}
+	if reasonsA != reasonsB {
+		changes = append(changes, "the reasons changed")
+	}
+	return changes, nil
+}
+
+//suppress adds a suppression for each leg of travel the alert was raised for, between the locations of the alert's
+//event and of its neighbour on that leg. A leg with either end's location unknown has nothing to suppress, and
+//couldn't have been found to be impossible anyway.
+func (s *sqlStorer) suppress(ctx context.Context, tx *sqlx.Tx, alert *model.Alert, now int64) error {
+	record, err := s.get(ctx, tx, event, alert.EventID)
+	if err != nil || record == nil || !record.Known() {
+		return err
+	}
+	neighbours := map[string]string{"preceding": alert.PrecedingEventID, "subsequent": alert.SubsequentEventID}
+	for _, reason := range alert.Reasons {
+		neighbourID := neighbours[reason.Leg]
+		if !reason.Triggered || reason.Suppressed || len(neighbourID) == 0 {
+			continue
+		}
+		neighbour, err := s.get(ctx, tx, event, neighbourID)
+		if err != nil {
+			return err
+		}
+		if neighbour == nil || !neighbour.Known() {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, tx.Rebind(insertSuppression), alert.ID, reason.Leg, alert.UserName,
+			record.Lat, record.Lon, suppressionRadius(record.Radius),
+			neighbour.Lat, neighbour.Lon, suppressionRadius(neighbour.Radius), now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//suppressionRadius is the accuracy radius, but at least the minimum radius of a suppression
+func suppressionRadius(radius uint16) uint16 {
+	if radius < model.MinSuppressionRadiusKm {
+		return model.MinSuppressionRadiusKm
+	}
+	return radius
+}
+
+//Suppressions gets the user's suppressions
+func (s *sqlStorer) Suppressions(ctx context.Context, user string) ([]*model.Suppression, error) {
+	var result []*model.Suppression
+	err := s.db.SelectContext(ctx, &result, s.db.Rebind(suppressions), user)
+	return result, err
+}
+
+func (s *sqlStorer) getAlert(ctx context.Context, q sqlx.QueryerContext, id int64) (*model.Alert, error) {
+	alerts := []*model.Alert{}
+	err := sqlx.SelectContext(ctx, q, &alerts, s.db.Rebind(alert), id)
+	if err != nil || len(alerts) == 0 {
+		return nil, err
+	}
+	err = sqlx.SelectContext(ctx, q, &alerts[0].History, s.db.Rebind(alertHistory), id)
+	if err != nil {
+		return nil, err
+	}
+	return alerts[0], nil
+}
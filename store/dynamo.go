@@ -2,44 +2,307 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/edwardsb/secureworks/model"
 	"github.com/guregu/dynamo"
+	"log"
+	"sort"
 )
 
+//userIndex is the global secondary index used for preceding and subsequent lookups. Reads from a global index are
+//always eventually consistent, so a neighbour written moments before can be missing from them, even with a single
+//instance. Idempotency never goes through it, events are looked up and put by id on the table itself.
+const userIndex = "username-sk-index"
+
+//dynamoEvent is the layout of an event in the dynamo table. The table is keyed on event_uuid, which makes
+//puts idempotent, and the user index orders a user's events by sk, which encodes (timestamp, event_uuid).
+type dynamoEvent struct {
+	EventID   string  `dynamo:"event_uuid,hash"`
+	UserName  string  `dynamo:"username" index:"username-sk-index,hash"`
+	SortKey   string  `dynamo:"sk" index:"username-sk-index,range"`
+	Timestamp int64   `dynamo:"ts"`
+	IP        string  `dynamo:"ip"`
+	Lat       float64 `dynamo:"lat"`
+	Lon       float64 `dynamo:"lon"`
+	Radius    uint16  `dynamo:"radius"`
+	Verdict   []byte  `dynamo:"verdict,omitempty"`
+
+	AnonymityChecked bool `dynamo:"anonymity_checked"`
+	Anonymous        bool `dynamo:"anonymous"`
+	VPN              bool `dynamo:"anonymous_vpn"`
+	Hosting          bool `dynamo:"hosting_provider"`
+	PublicProxy      bool `dynamo:"public_proxy"`
+	TorExitNode      bool `dynamo:"tor_exit_node"`
+
+	ASN          uint   `dynamo:"asn"`
+	Organization string `dynamo:"as_org"`
+
+	IPClass           string `dynamo:"ip_class"`
+	LocationSource    string `dynamo:"location_source"`
+	LocationLabel     string `dynamo:"location_label"`
+	LocationPrecision string `dynamo:"location_precision"`
+	Country           string `dynamo:"country"`
+	TimeZone          string `dynamo:"time_zone"`
+}
+
+func newDynamoEvent(record *model.Record) *dynamoEvent {
+	return &dynamoEvent{
+		EventID:   record.EventID,
+		UserName:  record.UserName,
+		SortKey:   sortKey(record.Timestamp, record.EventID),
+		Timestamp: record.Timestamp,
+		IP:        record.IP,
+		Lat:       record.Lat,
+		Lon:       record.Lon,
+		Radius:    record.Radius,
+		Verdict:   record.Verdict,
+
+		AnonymityChecked: record.Checked,
+		Anonymous:        record.Anonymous,
+		VPN:              record.VPN,
+		Hosting:          record.Hosting,
+		PublicProxy:      record.PublicProxy,
+		TorExitNode:      record.TorExitNode,
+
+		ASN:          record.ASN,
+		Organization: record.Organization,
+
+		IPClass:           record.IPClass,
+		LocationSource:    record.Source,
+		LocationLabel:     record.Label,
+		LocationPrecision: record.Precision,
+		Country:           record.Country,
+		TimeZone:          record.TimeZone,
+	}
+}
+
+func (e *dynamoEvent) record() *model.Record {
+	return &model.Record{
+		EventID:   e.EventID,
+		UserName:  e.UserName,
+		Timestamp: e.Timestamp,
+		IP:        e.IP,
+		IPClass:   e.IPClass,
+		Anonymity: model.Anonymity{
+			Checked:     e.AnonymityChecked,
+			Anonymous:   e.Anonymous,
+			VPN:         e.VPN,
+			Hosting:     e.Hosting,
+			PublicProxy: e.PublicProxy,
+			TorExitNode: e.TorExitNode,
+		},
+		Network: model.Network{
+			ASN:          e.ASN,
+			Organization: e.Organization,
+		},
+		Geo: model.Geo{
+			Lat:       e.Lat,
+			Lon:       e.Lon,
+			Radius:    e.Radius,
+			Source:    e.LocationSource,
+			Label:     e.LocationLabel,
+			Precision: e.LocationPrecision,
+			Country:   e.Country,
+			TimeZone:  e.TimeZone,
+		},
+		Verdict: e.Verdict,
+	}
+}
+
+//sortKey encodes (timestamp, event id) so that it sorts as a string in the same order the sql stores use.
+//Flipping the sign bit maps signed timestamps onto unsigned ones without changing their order, and zero padding
+//to the width of the largest uint64 keeps the string comparison numeric.
+func sortKey(timestamp int64, eventID string) string {
+	return fmt.Sprintf("%020d#%s", uint64(timestamp)^(1<<63), eventID)
+}
+
+//DynamoStorer satisfies the Storer and UserLister interfaces, backed by DynamoDB. It has no alerts, network lists or
+//user history.
 type DynamoStorer struct {
-	db *dynamo.DB
+	db    *dynamo.DB
+	table string
+}
+
+//NewDynamoStore is a constructor that takes the DynamoDB api, this is so you can configure the session
+//(endpoint, region, credentials) before it gets here, or hand it a fake in tests.
+func NewDynamoStore(client dynamodbiface.DynamoDBAPI, table string) *DynamoStorer {
+	return &DynamoStorer{db: dynamo.NewFromIface(client), table: table}
+}
+
+//Open creates the table and its index when they don't exist yet, and waits for the table to become active
+func (s *DynamoStorer) Open() error {
+	log.Println("opening dynamo store")
+	ctx := context.Background()
+	_, err := s.db.Table(s.table).Describe().RunWithContext(ctx)
+	if err == nil {
+		return nil
+	}
+	if ae, ok := err.(awserr.Error); !ok || ae.Code() != dynamodb.ErrCodeResourceNotFoundException {
+		return err
+	}
+
+	log.Printf("creating dynamo table %s\n", s.table)
+	err = s.db.CreateTable(s.table, dynamoEvent{}).
+		OnDemand(true).
+		Project(userIndex, dynamo.AllProjection).
+		RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return s.db.Client().WaitUntilTableExistsWithContext(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(s.table)})
+}
+
+//Close is a no-op, the dynamo client holds no resources that need releasing
+func (s *DynamoStorer) Close() error {
+	log.Println("closing dynamo store")
+	return nil
+}
+
+//Put will store the user login event. Dynamo has no auto increment ids, so the returned id is always 0.
+//If the event id has been stored before nothing is written and ErrDuplicateEvent is returned, the condition is
+//checked against the table itself, so a retried event is never stored twice.
+func (s *DynamoStorer) Put(ctx context.Context, record *model.Record) (int64, error) {
+	err := s.db.Table(s.table).
+		Put(newDynamoEvent(record)).
+		If("attribute_not_exists($)", "event_uuid").
+		RunWithContext(ctx)
+	if isConditionalCheckFailed(err) {
+		return 0, ErrDuplicateEvent
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, nil
 }
 
-func NewDynamoStore() *DynamoStorer {
-	config := &aws.Config{Endpoint: aws.String("localhost:8000"), Region: aws.String("local-test")}
-	sess, _ := session.NewSession(config)
-	return &DynamoStorer{db: dynamo.New(sess, config)}
+//Event gets the stored event by its event id, or nil if it hasn't been stored. It is a consistent read of the table,
+//so an event that was just stored is always found.
+func (s *DynamoStorer) Event(ctx context.Context, eventID string) (*model.Record, error) {
+	var result dynamoEvent
+	err := s.db.Table(s.table).
+		Get("event_uuid", eventID).
+		Consistent(true).
+		OneWithContext(ctx, &result)
+	if err == dynamo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.record(), nil
 }
 
-func (s *DynamoStorer) Put(ctx context.Context, record *model.Record) error {
-	return s.db.Table("events").Put(record).RunWithContext(ctx)
+//SaveVerdict stores the serialized verdict alongside the event
+func (s *DynamoStorer) SaveVerdict(ctx context.Context, eventID string, verdict []byte) error {
+	return s.db.Table(s.table).
+		Update("event_uuid", eventID).
+		Set("verdict", verdict).
+		If("attribute_exists($)", "event_uuid").
+		RunWithContext(ctx)
 }
 
+//PrecedingAccess gets the closest access that happened before timestamp for the specified user.
+//Events sharing the timestamp are ordered by event id. It reads the user index, so it can miss an access that was
+//stored moments before.
+func (s *DynamoStorer) PrecedingAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	return s.getEvent(ctx, user, sortKey(timestamp, eventID), dynamo.Less, dynamo.Descending)
+}
 
-func (s *DynamoStorer) PrecedingAccess(ctx context.Context, user string, timestamp int64) (*model.Record, error) {
-	return s.getEvent(ctx, user, timestamp, dynamo.Less, dynamo.Descending)
+//SubsequentAccess gets the closest access that happened after timestamp for the specified user.
+//Events sharing the timestamp are ordered by event id. It reads the user index, so it can miss an access that was
+//stored moments before.
+func (s *DynamoStorer) SubsequentAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	return s.getEvent(ctx, user, sortKey(timestamp, eventID), dynamo.Greater, dynamo.Ascending)
 }
 
-func (s *DynamoStorer) SubsequentAccess(ctx context.Context, user string, timestamp int64) (*model.Record, error) {
-	return s.getEvent(ctx, user, timestamp, dynamo.Greater, dynamo.Ascending)
+//Events gets the user's events matching the filter, in (timestamp, event id) order. A query can only have one
+//condition on the sort key, so it is the lower bound, and the events from To on, which sort after everything
+//else, are cut off afterwards.
+func (s *DynamoStorer) Events(ctx context.Context, user string, filter model.EventFilter) ([]*model.Record, error) {
+	query := s.db.Table(s.table).
+		Get("username", user).
+		Index(userIndex).
+		Order(dynamo.Ascending)
+
+	lower, op := "", dynamo.Operator(dynamo.GreaterOrEqual)
+	if filter.From != nil {
+		lower = sortKey(*filter.From, "")
+	}
+	if filter.After != nil {
+		if after := sortKey(filter.After.Timestamp, filter.After.EventID); after >= lower {
+			lower, op = after, dynamo.Greater
+		}
+	}
+	switch {
+	case len(lower) > 0:
+		query = query.Range("sk", op, lower)
+	case filter.To != nil:
+		query = query.Range("sk", dynamo.Less, sortKey(*filter.To, ""))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(int64(filter.Limit))
+	}
+
+	var results []dynamoEvent
+	err := query.AllWithContext(ctx, &results)
+	if err != nil {
+		return nil, err
+	}
+	var records []*model.Record
+	for _, result := range results {
+		if filter.To != nil && result.Timestamp >= *filter.To {
+			break
+		}
+		records = append(records, result.record())
+	}
+	return records, nil
+}
+
+//Users lists every user with stored events, in order. Dynamo can't select distinct values, so this scans the user
+//index for the username of every event.
+func (s *DynamoStorer) Users(ctx context.Context) ([]string, error) {
+	iter := s.db.Table(s.table).
+		Scan().
+		Index(userIndex).
+		Project("username").
+		Iter()
+	seen := map[string]bool{}
+	var result dynamoEvent
+	for iter.NextWithContext(ctx, &result) {
+		seen[result.UserName] = true
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	users := make([]string, 0, len(seen))
+	for user := range seen {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	return users, nil
 }
 
-func (s *DynamoStorer) getEvent(ctx context.Context, user string, ts int64, op dynamo.Operator, order dynamo.Order) (*model.Record, error){
-	var result model.Record
-	var err error
-	err = s.db.Table("events").
+func (s *DynamoStorer) getEvent(ctx context.Context, user string, key string, op dynamo.Operator, order dynamo.Order) (*model.Record, error) {
+	var result dynamoEvent
+	err := s.db.Table(s.table).
 		Get("username", user).
-		Range("ts", op, ts).
+		Range("sk", op, key).
+		Index(userIndex).
 		Order(order).Limit(1).OneWithContext(ctx, &result)
+	if err == dynamo.ErrNotFound {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return result.record(), nil
+}
+
+func isConditionalCheckFailed(err error) bool {
+	ae, ok := err.(awserr.Error)
+	return ok && ae.Code() == dynamodb.ErrCodeConditionalCheckFailedException
 }
@@ -0,0 +1,355 @@
+package store
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//fakeDynamo is an in-process stand in for the parts of the DynamoDB api the dynamo store uses.
+//Calls it doesn't implement panic on the embedded nil interface, which is what we want in tests.
+type fakeDynamo struct {
+	dynamodbiface.DynamoDBAPI
+	mu     sync.Mutex
+	tables map[string]*fakeTable
+}
+
+type fakeItem map[string]*dynamodb.AttributeValue
+
+type fakeTable struct {
+	description *dynamodb.TableDescription
+	items       map[string]fakeItem
+}
+
+func newFakeDynamo() *fakeDynamo {
+	return &fakeDynamo{tables: map[string]*fakeTable{}}
+}
+
+func (f *fakeDynamo) table(name *string) (*fakeTable, error) {
+	t, ok := f.tables[aws.StringValue(name)]
+	if !ok {
+		return nil, awserr.New(dynamodb.ErrCodeResourceNotFoundException, "table not found", nil)
+	}
+	return t, nil
+}
+
+func (f *fakeDynamo) CreateTableWithContext(ctx aws.Context, input *dynamodb.CreateTableInput, opts ...request.Option) (*dynamodb.CreateTableOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.tables[aws.StringValue(input.TableName)]; ok {
+		return nil, awserr.New(dynamodb.ErrCodeResourceInUseException, "table exists", nil)
+	}
+	description := &dynamodb.TableDescription{
+		TableName:            input.TableName,
+		TableStatus:          aws.String(dynamodb.TableStatusActive),
+		KeySchema:            input.KeySchema,
+		AttributeDefinitions: input.AttributeDefinitions,
+	}
+	for _, gsi := range input.GlobalSecondaryIndexes {
+		description.GlobalSecondaryIndexes = append(description.GlobalSecondaryIndexes, &dynamodb.GlobalSecondaryIndexDescription{
+			IndexName:             gsi.IndexName,
+			IndexArn:              aws.String("arn:aws:dynamodb:local:000000000000:table/" + aws.StringValue(input.TableName) + "/index/" + aws.StringValue(gsi.IndexName)),
+			IndexStatus:           aws.String(dynamodb.IndexStatusActive),
+			KeySchema:             gsi.KeySchema,
+			Projection:            gsi.Projection,
+			ProvisionedThroughput: &dynamodb.ProvisionedThroughputDescription{ReadCapacityUnits: aws.Int64(0), WriteCapacityUnits: aws.Int64(0)},
+		})
+	}
+	f.tables[aws.StringValue(input.TableName)] = &fakeTable{description: description, items: map[string]fakeItem{}}
+	return &dynamodb.CreateTableOutput{TableDescription: description}, nil
+}
+
+func (f *fakeDynamo) DescribeTableWithContext(ctx aws.Context, input *dynamodb.DescribeTableInput, opts ...request.Option) (*dynamodb.DescribeTableOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	return &dynamodb.DescribeTableOutput{Table: t.description}, nil
+}
+
+func (f *fakeDynamo) WaitUntilTableExistsWithContext(ctx aws.Context, input *dynamodb.DescribeTableInput, opts ...request.WaiterOption) error {
+	_, err := f.DescribeTableWithContext(ctx, input)
+	return err
+}
+
+func (f *fakeDynamo) PutItemWithContext(ctx aws.Context, input *dynamodb.PutItemInput, opts ...request.Option) (*dynamodb.PutItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key := t.key(input.Item, t.description.KeySchema)
+	err = checkCondition(t.items[key], input.ConditionExpression, input.ExpressionAttributeNames)
+	if err != nil {
+		return nil, err
+	}
+	t.items[key] = input.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeDynamo) GetItemWithContext(ctx aws.Context, input *dynamodb.GetItemInput, opts ...request.Option) (*dynamodb.GetItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	// the store looks events up by id to make them idempotent, which is only safe with a consistent read
+	if !aws.BoolValue(input.ConsistentRead) {
+		return nil, errors.New("fake dynamo: event lookups must be consistent reads")
+	}
+	return &dynamodb.GetItemOutput{Item: t.items[t.key(input.Key, t.description.KeySchema)]}, nil
+}
+
+func (f *fakeDynamo) DeleteItemWithContext(ctx aws.Context, input *dynamodb.DeleteItemInput, opts ...request.Option) (*dynamodb.DeleteItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key := t.key(input.Key, t.description.KeySchema)
+	err = checkCondition(t.items[key], input.ConditionExpression, input.ExpressionAttributeNames)
+	if err != nil {
+		return nil, err
+	}
+	delete(t.items, key)
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+//UpdateItemWithContext supports update expressions made of SET clauses only
+func (f *fakeDynamo) UpdateItemWithContext(ctx aws.Context, input *dynamodb.UpdateItemInput, opts ...request.Option) (*dynamodb.UpdateItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key := t.key(input.Key, t.description.KeySchema)
+	existing := t.items[key]
+	err = checkCondition(existing, input.ConditionExpression, input.ExpressionAttributeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	item := fakeItem{}
+	for k, v := range existing {
+		item[k] = v
+	}
+	for k, v := range input.Key {
+		item[k] = v
+	}
+	expr := strings.TrimSpace(aws.StringValue(input.UpdateExpression))
+	if !strings.HasPrefix(expr, "SET ") {
+		return nil, errors.Errorf("fake dynamo: unsupported update expression %q", expr)
+	}
+	for _, clause := range strings.Split(strings.TrimPrefix(expr, "SET "), ",") {
+		parts := strings.Split(clause, "=")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("fake dynamo: unsupported update clause %q", clause)
+		}
+		name := resolveName(strings.TrimSpace(parts[0]), input.ExpressionAttributeNames)
+		item[name] = input.ExpressionAttributeValues[strings.TrimSpace(parts[1])]
+	}
+	t.items[key] = item
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+//QueryWithContext supports the legacy KeyConditions the dynamo library sends, on the table or a global index
+func (f *fakeDynamo) QueryWithContext(ctx aws.Context, input *dynamodb.QueryInput, opts ...request.Option) (*dynamodb.QueryOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	schema := t.description.KeySchema
+	if input.IndexName != nil {
+		schema = nil
+		for _, gsi := range t.description.GlobalSecondaryIndexes {
+			if aws.StringValue(gsi.IndexName) == aws.StringValue(input.IndexName) {
+				schema = gsi.KeySchema
+			}
+		}
+		if schema == nil {
+			return nil, errors.Errorf("fake dynamo: unknown index %s", aws.StringValue(input.IndexName))
+		}
+	}
+	rangeKey := keyName(schema, dynamodb.KeyTypeRange)
+
+	var items []fakeItem
+	for _, item := range t.items {
+		if matchesConditions(item, input.KeyConditions) {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return compareValues(items[i][rangeKey], items[j][rangeKey]) < 0
+	})
+	if input.ScanIndexForward != nil && !*input.ScanIndexForward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	if input.ExclusiveStartKey != nil {
+		start := t.key(input.ExclusiveStartKey, t.description.KeySchema)
+		for i, item := range items {
+			if t.key(item, t.description.KeySchema) == start {
+				items = items[i+1:]
+				break
+			}
+		}
+	}
+
+	output := &dynamodb.QueryOutput{}
+	if input.Limit != nil && int64(len(items)) > *input.Limit {
+		items = items[:*input.Limit]
+		last := items[len(items)-1]
+		output.LastEvaluatedKey = fakeItem{}
+		for _, k := range append(append([]*dynamodb.KeySchemaElement{}, t.description.KeySchema...), schema...) {
+			output.LastEvaluatedKey[aws.StringValue(k.AttributeName)] = last[aws.StringValue(k.AttributeName)]
+		}
+	}
+	for _, item := range items {
+		output.Items = append(output.Items, item)
+	}
+	output.Count = aws.Int64(int64(len(items)))
+	return output, nil
+}
+
+//ScanWithContext returns every item of the table in a single page, filters and projections are ignored
+func (f *fakeDynamo) ScanWithContext(ctx aws.Context, input *dynamodb.ScanInput, opts ...request.Option) (*dynamodb.ScanOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, err := f.table(input.TableName)
+	if err != nil {
+		return nil, err
+	}
+	output := &dynamodb.ScanOutput{}
+	for _, item := range t.items {
+		output.Items = append(output.Items, item)
+	}
+	output.Count = aws.Int64(int64(len(output.Items)))
+	output.ScannedCount = output.Count
+	return output, nil
+}
+
+func (t *fakeTable) key(item map[string]*dynamodb.AttributeValue, schema []*dynamodb.KeySchemaElement) string {
+	var parts []string
+	for _, k := range schema {
+		parts = append(parts, valueString(item[aws.StringValue(k.AttributeName)]))
+	}
+	return strings.Join(parts, "|")
+}
+
+func keyName(schema []*dynamodb.KeySchemaElement, keyType string) string {
+	for _, k := range schema {
+		if aws.StringValue(k.KeyType) == keyType {
+			return aws.StringValue(k.AttributeName)
+		}
+	}
+	return ""
+}
+
+func resolveName(name string, names map[string]*string) string {
+	if n, ok := names[name]; ok {
+		return aws.StringValue(n)
+	}
+	return name
+}
+
+//checkCondition supports condition expressions made of attribute_exists and attribute_not_exists joined by AND
+func checkCondition(existing fakeItem, condition *string, names map[string]*string) error {
+	if condition == nil {
+		return nil
+	}
+	for _, clause := range strings.Split(*condition, " AND ") {
+		clause = strings.Trim(strings.TrimSpace(clause), "()")
+		var ok bool
+		switch {
+		case strings.HasPrefix(clause, "attribute_not_exists("):
+			_, exists := existing[resolveName(strings.TrimSuffix(strings.TrimPrefix(clause, "attribute_not_exists("), ")"), names)]
+			ok = !exists
+		case strings.HasPrefix(clause, "attribute_exists("):
+			_, ok = existing[resolveName(strings.TrimSuffix(strings.TrimPrefix(clause, "attribute_exists("), ")"), names)]
+		default:
+			return errors.Errorf("fake dynamo: unsupported condition %q", clause)
+		}
+		if !ok {
+			return awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "the conditional request failed", nil)
+		}
+	}
+	return nil
+}
+
+func matchesConditions(item fakeItem, conditions map[string]*dynamodb.Condition) bool {
+	for name, cond := range conditions {
+		v, ok := item[name]
+		if !ok {
+			return false
+		}
+		args := cond.AttributeValueList
+		c := compareValues(v, args[0])
+		switch aws.StringValue(cond.ComparisonOperator) {
+		case dynamodb.ComparisonOperatorEq:
+			ok = c == 0
+		case dynamodb.ComparisonOperatorLt:
+			ok = c < 0
+		case dynamodb.ComparisonOperatorLe:
+			ok = c <= 0
+		case dynamodb.ComparisonOperatorGt:
+			ok = c > 0
+		case dynamodb.ComparisonOperatorGe:
+			ok = c >= 0
+		case dynamodb.ComparisonOperatorBetween:
+			ok = c >= 0 && compareValues(v, args[1]) <= 0
+		case dynamodb.ComparisonOperatorBeginsWith:
+			ok = strings.HasPrefix(valueString(v), valueString(args[0]))
+		default:
+			ok = false
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func valueString(v *dynamodb.AttributeValue) string {
+	if v == nil {
+		return ""
+	}
+	if v.N != nil {
+		return *v.N
+	}
+	if v.B != nil {
+		return string(v.B)
+	}
+	return aws.StringValue(v.S)
+}
+
+func compareValues(a, b *dynamodb.AttributeValue) int {
+	if a != nil && b != nil && a.N != nil && b.N != nil {
+		x, _ := strconv.ParseFloat(*a.N, 64)
+		y, _ := strconv.ParseFloat(*b.N, 64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(valueString(a), valueString(b))
+}
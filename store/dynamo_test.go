@@ -0,0 +1,52 @@
+package store
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newTestDynamoStorer(t *testing.T) *DynamoStorer {
+	store := NewDynamoStore(newFakeDynamo(), "events")
+	require.NoError(t, store.Open())
+	// opening again must find the table rather than trying to create it
+	require.NoError(t, store.Open())
+	return store
+}
+
+func TestDynamoStorer_NearestAccess(t *testing.T) {
+	store := newTestDynamoStorer(t)
+	defer store.Close()
+	testNearestAccess(t, store)
+}
+
+func TestDynamoStorer_NearestAccessTieBreak(t *testing.T) {
+	store := newTestDynamoStorer(t)
+	defer store.Close()
+	testNearestAccessTieBreak(t, store)
+}
+
+func TestDynamoStorer_Events(t *testing.T) {
+	store := newTestDynamoStorer(t)
+	defer store.Close()
+	testEvents(t, store)
+}
+
+func TestDynamoStorer_Users(t *testing.T) {
+	store := newTestDynamoStorer(t)
+	defer store.Close()
+	testUsers(t, store)
+}
+
+func TestDynamoStorer_Idempotent(t *testing.T) {
+	store := newTestDynamoStorer(t)
+	defer store.Close()
+	testIdempotent(t, store)
+}
+
+func TestSortKey(t *testing.T) {
+	require.True(t, sortKey(-9223372036854775808, "a") < sortKey(-1, "a"))
+	require.True(t, sortKey(-1, "a") < sortKey(0, "a"))
+	require.True(t, sortKey(0, "b") < sortKey(1, "a"))
+	require.True(t, sortKey(1, "a") < sortKey(1, "b"))
+	require.True(t, sortKey(1561600005, "z") < sortKey(9223372036854775807, "a"))
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+)
+
+//the history lookups only need to know whether there is a matching earlier event, so they stop at the first one
+
+const seenCountry = `SELECT 1
+FROM login_events
+WHERE username = ? AND country = ? AND (timestamp, event_uuid) < (?, ?)
+LIMIT 1;`
+
+const seenASN = `SELECT 1
+FROM login_events
+WHERE username = ? AND asn = ? AND (timestamp, event_uuid) < (?, ?)
+LIMIT 1;`
+
+//SeenCountry is true when the user has an event from the country before the given position
+func (s *sqlStorer) SeenCountry(ctx context.Context, user string, country string, timestamp int64, eventID string) (bool, error) {
+	return s.exists(ctx, seenCountry, user, country, timestamp, eventID)
+}
+
+//SeenASN is true when the user has an event from the autonomous system before the given position
+func (s *sqlStorer) SeenASN(ctx context.Context, user string, asn uint, timestamp int64, eventID string) (bool, error) {
+	return s.exists(ctx, seenASN, user, asn, timestamp, eventID)
+}
+
+func (s *sqlStorer) exists(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	var found int
+	err := s.db.QueryRowxContext(ctx, s.db.Rebind(query), args...).Scan(&found)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
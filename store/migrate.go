@@ -0,0 +1,218 @@
+package store
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/resources"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const schemaVersion = `create table if not exists schema_version
+(
+	version integer not null
+		constraint schema_version_pk
+			primary key,
+	name text not null,
+	applied_at bigint not null
+);`
+
+const appliedVersions = `SELECT version, applied_at FROM schema_version;`
+
+const insertVersion = `INSERT INTO schema_version(version, name, applied_at) VALUES (?, ?, ?);`
+
+const deleteVersion = `DELETE FROM schema_version WHERE version = ?;`
+
+//migrationLockNamespace keeps the migration advisory lock apart from the per user locks
+const migrationLockNamespace = 0x534d
+
+//migrationLock serializes migrators on postgres, so several instances starting at once don't race each other
+const migrationLock = `SELECT pg_advisory_xact_lock(?, 0);`
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+//Migration is a single versioned schema change, embedded in resources under migrations/<dialect>
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+//MigrationStatus is a migration along with when it was applied, AppliedAt is zero when it hasn't been
+type MigrationStatus struct {
+	Migration
+	AppliedAt int64
+}
+
+//Applied is true when the migration has been applied to the database
+func (m MigrationStatus) Applied() bool {
+	return m.AppliedAt != 0
+}
+
+//Migrations loads the embedded migrations for the dialect, ordered by version
+func Migrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	names, err := resources.AssetDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "no migrations for %s", dialect)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, name := range names {
+		match := migrationFile.FindStringSubmatch(name)
+		if match == nil {
+			return nil, errors.Errorf("unexpected migration file %s", name)
+		}
+		version, _ := strconv.Atoi(match[1])
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, errors.Errorf("migration %d has conflicting names %s and %s", version, m.Name, match[2])
+		}
+		body := resources.Get(path.Join(dir, name))
+		if match[3] == "up" {
+			m.Up = body
+		} else {
+			m.Down = body
+		}
+	}
+
+	var migrations []Migration
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, errors.Errorf("migration %d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+//Migrator applies the embedded migrations for a sql backend and records them in the schema_version table.
+//Each call runs in a single transaction, so a failed migration leaves the schema as it was.
+type Migrator struct {
+	db         *sqlx.DB
+	dialect    string
+	migrations []Migration
+}
+
+//NewMigrator creates a migrator for db, dialect picks the set of migrations and is the sqlx driver name
+func NewMigrator(db *sqlx.DB, dialect string) (*Migrator, error) {
+	migrations, err := Migrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+//Up applies every pending migration in version order, returning the ones it applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.inTx(ctx, func(tx *sqlx.Tx, status []MigrationStatus) error {
+		for _, s := range status {
+			if s.Applied() {
+				continue
+			}
+			_, err := tx.ExecContext(ctx, s.Up)
+			if err != nil {
+				return errors.Wrapf(err, "failed to apply migration %d_%s", s.Version, s.Name)
+			}
+			_, err = tx.ExecContext(ctx, tx.Rebind(insertVersion), s.Version, s.Name, time.Now().Unix())
+			if err != nil {
+				return err
+			}
+			applied = append(applied, s.Migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+//Down reverts the latest steps applied migrations, newest first, returning the ones it reverted
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.inTx(ctx, func(tx *sqlx.Tx, status []MigrationStatus) error {
+		for i := len(status) - 1; i >= 0 && len(reverted) < steps; i-- {
+			s := status[i]
+			if !s.Applied() {
+				continue
+			}
+			_, err := tx.ExecContext(ctx, s.Down)
+			if err != nil {
+				return errors.Wrapf(err, "failed to revert migration %d_%s", s.Version, s.Name)
+			}
+			_, err = tx.ExecContext(ctx, tx.Rebind(deleteVersion), s.Version)
+			if err != nil {
+				return err
+			}
+			reverted = append(reverted, s.Migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+//Status lists every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var status []MigrationStatus
+	err := m.inTx(ctx, func(tx *sqlx.Tx, s []MigrationStatus) error {
+		status = s
+		return nil
+	})
+	return status, err
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sqlx.Tx, status []MigrationStatus) error) error {
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = m.run(ctx, tx, fn)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) run(ctx context.Context, tx *sqlx.Tx, fn func(tx *sqlx.Tx, status []MigrationStatus) error) error {
+	if m.dialect == "postgres" {
+		_, err := tx.ExecContext(ctx, tx.Rebind(migrationLock), migrationLockNamespace)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := tx.ExecContext(ctx, schemaVersion)
+	if err != nil {
+		return err
+	}
+
+	var rows []struct {
+		Version   int   `db:"version"`
+		AppliedAt int64 `db:"applied_at"`
+	}
+	err = tx.SelectContext(ctx, &rows, appliedVersions)
+	if err != nil {
+		return err
+	}
+	appliedAt := map[int]int64{}
+	for _, r := range rows {
+		appliedAt[r.Version] = r.AppliedAt
+	}
+
+	var status []MigrationStatus
+	for _, migration := range m.migrations {
+		status = append(status, MigrationStatus{Migration: migration, AppliedAt: appliedAt[migration.Version]})
+	}
+	return fn(tx, status)
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMigrations_DialectsInStep(t *testing.T) {
+	sqlite, err := Migrations("sqlite")
+	require.NoError(t, err)
+	postgres, err := Migrations("postgres")
+	require.NoError(t, err)
+	require.NotEmpty(t, sqlite)
+	require.Equal(t, len(sqlite), len(postgres))
+	for i := range sqlite {
+		require.Equal(t, i+1, sqlite[i].Version)
+		require.Equal(t, sqlite[i].Version, postgres[i].Version)
+		require.Equal(t, sqlite[i].Name, postgres[i].Name)
+	}
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	ctx := context.Background()
+	migrator, err := NewMigrator(sqlx.NewDb(db, "sqlite3"), "sqlite")
+	require.NoError(t, err)
+	all, err := Migrations("sqlite")
+	require.NoError(t, err)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, len(all))
+	for _, s := range status {
+		require.False(t, s.Applied())
+	}
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Equal(t, all, applied)
+
+	// nothing left to do the second time around
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	status, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, s := range status {
+		require.True(t, s.Applied())
+	}
+
+	reverted, err := migrator.Down(ctx, len(all))
+	require.NoError(t, err)
+	require.Len(t, reverted, len(all))
+	require.Equal(t, all[0], reverted[len(reverted)-1])
+
+	var tables int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'login_events'`).Scan(&tables))
+	require.Equal(t, 0, tables)
+}
+
+func TestMigrator_LegacyEvents(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	// the table as it was before events were append only
+	_, err = db.Exec(`create table events (id INTEGER constraint events_pk primary key autoincrement, username text,
+		timestamp int, lat real, lon real, radius int, ip text, anonymous boolean, constraint events_pk_2 unique (username, ip));
+		insert into events (username, timestamp, lat, lon, radius, ip, anonymous) values
+		('foo', 100, 40.7128, -74.006, 5, '68.193.88.102', false),
+		('foo', 200, 34.0522, -118.2437, 5, '68.193.88.103', false);`)
+	require.NoError(t, err)
+
+	storer := NewSqliteDb(db)
+	require.NoError(t, storer.Open())
+	record, err := storer.PrecedingAccess(context.Background(), "foo", 300, "")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.Equal(t, "legacy-2", record.EventID)
+	require.Equal(t, int64(200), record.Timestamp)
+	require.Equal(t, "68.193.88.103", record.IP)
+	require.Equal(t, 34.0522, record.Lat)
+
+	var tables int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'events'`).Scan(&tables))
+	require.Equal(t, 0, tables)
+}
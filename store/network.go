@@ -0,0 +1,53 @@
+package store
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+)
+
+const networkColumns = `id, list, cidr, username, comment, created_at`
+
+const insertNetwork = `INSERT INTO network_lists(list, cidr, username, comment, created_at)
+VALUES (?, ?, ?, ?, ?)`
+
+const deleteNetwork = `DELETE FROM network_lists
+WHERE id = ?;`
+
+const networks = `SELECT ` + networkColumns + `
+FROM network_lists
+WHERE username = '' OR username = ?
+ORDER BY id;`
+
+const allNetworks = `SELECT ` + networkColumns + `
+FROM network_lists
+ORDER BY id;`
+
+//DeleteNetwork removes the list entry, ErrNotFound is returned if there is no such entry
+func (s *sqlStorer) DeleteNetwork(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, s.db.Rebind(deleteNetwork), id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+//Networks gets the global list entries and the ones for user
+func (s *sqlStorer) Networks(ctx context.Context, user string) ([]*model.NetworkListEntry, error) {
+	var entries []*model.NetworkListEntry
+	err := s.db.SelectContext(ctx, &entries, s.db.Rebind(networks), user)
+	return entries, err
+}
+
+//AllNetworks gets every list entry
+func (s *sqlStorer) AllNetworks(ctx context.Context) ([]*model.NetworkListEntry, error) {
+	var entries []*model.NetworkListEntry
+	err := s.db.SelectContext(ctx, &entries, allNetworks)
+	return entries, err
+}
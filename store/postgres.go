@@ -0,0 +1,204 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" //import postgres dialect
+	"log"
+	"sync"
+)
+
+const postgresInsert = `INSERT INTO login_events(event_uuid, username, timestamp, lat, lon, radius, ip,
+	anonymity_checked, anonymous, anonymous_vpn, hosting_provider, public_proxy, tor_exit_node, asn, as_org,
+	ip_class, location_source, location_label, location_precision, country, time_zone)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(event_uuid) DO NOTHING
+RETURNING id;`
+
+//userLockNamespace keeps our per user advisory locks from colliding with any other advisory locks in the database
+const userLockNamespace = 0x5357
+
+//writers take the per user lock exclusively and readers take it shared, so a neighbour lookup waits for any
+//insert for the same user that is still in flight on another instance, instead of missing it.
+const lockUser = `SELECT pg_advisory_xact_lock(?, hashtext(?));`
+
+const lockUserShared = `SELECT pg_advisory_xact_lock_shared(?, hashtext(?));`
+
+//detectLockNamespace is for the lock LockUser holds while an event is detected. It can't share the namespace of
+//the lock Put takes, since the detector's own Put would then wait on it.
+const detectLockNamespace = 0x5344
+
+//PostgresStorer satisfies the Storer interface on PostgreSQL, it is safe for several instances to share a database
+type PostgresStorer struct {
+	sqlStorer
+	// lockSlots bounds the user locks held at once, so they can't take every connection in the pool, see LockUser
+	lockSlots     chan struct{}
+	lockSlotsOnce sync.Once
+}
+
+//NewPostgresDb is a constructor that takes a *sql.DB, this is so you can modify the driver before it gets here.
+func NewPostgresDb(db *sql.DB) *PostgresStorer {
+	return &PostgresStorer{sqlStorer: newSQLStorer(sqlx.NewDb(db, "postgres"), "postgres")}
+}
+
+//Open pings the database and applies any pending migrations, unless AutoMigrate has been turned off
+func (s *PostgresStorer) Open() error {
+	log.Println("opening postgres store")
+	err := s.db.Ping()
+	if err != nil {
+		return err
+	}
+	return s.migrate()
+}
+
+//Close closes the underlying db
+func (s *PostgresStorer) Close() error {
+	err := s.db.Close()
+	if err != nil {
+		return err
+	}
+	log.Println("closing postgres store")
+	return nil
+}
+
+//Put will store the user login event into the database while holding the user's lock, it will also update the
+//record model with the ID that was inserted. If the event id has been stored before nothing is written and
+//ErrDuplicateEvent is returned.
+func (s *PostgresStorer) Put(ctx context.Context, record *model.Record) (int64, error) {
+	var id int64
+	err := s.withUserLock(ctx, lockUser, record.UserName, func(tx *sqlx.Tx) error {
+		return tx.GetContext(ctx, &id, tx.Rebind(postgresInsert), record.EventID, record.UserName, record.Timestamp, record.Lat, record.Lon, record.Radius, record.IP,
+			record.Checked, record.Anonymous, record.VPN, record.Hosting, record.PublicProxy, record.TorExitNode,
+			record.ASN, record.Organization, record.IPClass, record.Source, record.Label, record.Precision,
+			record.Country, record.TimeZone)
+	})
+	if err == sql.ErrNoRows {
+		return 0, ErrDuplicateEvent
+	}
+	if err != nil {
+		return 0, err
+	}
+	record.ID = id
+	return id, nil
+}
+
+//PutAlert stores the alert, it will also update the alert with the ID that was inserted. If the event already has
+//an alert nothing is written and ErrDuplicateAlert is returned.
+func (s *PostgresStorer) PutAlert(ctx context.Context, alert *model.Alert) (int64, error) {
+	var id int64
+	err := s.db.GetContext(ctx, &id, s.db.Rebind(insertAlert+` RETURNING id;`), alertArgs(alert)...)
+	if err == sql.ErrNoRows {
+		return 0, ErrDuplicateAlert
+	}
+	if err != nil {
+		return 0, err
+	}
+	alert.ID = id
+	return id, nil
+}
+
+//PutNetwork adds an entry to the allow or deny list, it will also update the entry with the ID that was inserted
+func (s *PostgresStorer) PutNetwork(ctx context.Context, entry *model.NetworkListEntry) (int64, error) {
+	var id int64
+	err := s.db.GetContext(ctx, &id, s.db.Rebind(insertNetwork+` RETURNING id;`), entry.List, entry.CIDR, entry.UserName, entry.Comment, entry.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	entry.ID = id
+	return id, nil
+}
+
+//PrecedingAccess gets the closest access that happened before timestamp for the specified user.
+//Events sharing the timestamp are ordered by event id.
+func (s *PostgresStorer) PrecedingAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	return s.getLocked(ctx, preceeding, user, timestamp, eventID)
+}
+
+//SubsequentAccess gets the closest access that happened after timestamp for the specified user.
+//Events sharing the timestamp are ordered by event id.
+func (s *PostgresStorer) SubsequentAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	return s.getLocked(ctx, subsequent, user, timestamp, eventID)
+}
+
+func (s *PostgresStorer) getLocked(ctx context.Context, query string, user string, timestamp int64, eventID string) (*model.Record, error) {
+	var record *model.Record
+	err := s.withUserLock(ctx, lockUserShared, user, func(tx *sqlx.Tx) error {
+		var err error
+		record, err = s.get(ctx, tx, query, user, timestamp, eventID)
+		return err
+	})
+	return record, err
+}
+
+//LockUser holds the user's advisory lock in a transaction of its own, which does nothing else. Unlock rolls it back,
+//so the lock can't be left behind on a pooled connection.
+//
+//The lock's transaction keeps a connection for as long as the user is locked, and the work done under it needs
+//another. When the pool is limited with SetMaxOpenConns, at most one less lock than the pool has connections is held
+//at once, so there is always a connection left for the work, and LockUser waits for a lock to be released first.
+func (s *PostgresStorer) LockUser(ctx context.Context, user string) (func(), error) {
+	release, err := s.takeLockSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(lockUser), detectLockNamespace, user)
+	if err != nil {
+		tx.Rollback()
+		release()
+		return nil, err
+	}
+	return func() {
+		tx.Rollback()
+		release()
+	}, nil
+}
+
+//takeLockSlot waits for one of the lock slots, the pool size is read the first time so that SetMaxOpenConns can be
+//called any time before the store is used
+func (s *PostgresStorer) takeLockSlot(ctx context.Context) (func(), error) {
+	s.lockSlotsOnce.Do(func() {
+		if max := s.db.Stats().MaxOpenConnections; max > 0 {
+			s.lockSlots = make(chan struct{}, max-1)
+		}
+	})
+	if s.lockSlots == nil {
+		return func() {}, nil
+	}
+	if cap(s.lockSlots) == 0 {
+		return nil, errors.New("locking users needs a pool of at least 2 connections")
+	}
+	select {
+	case s.lockSlots <- struct{}{}:
+		return func() { <-s.lockSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+//withUserLock runs fn in a transaction holding the advisory lock for user, the lock is released when the
+//transaction ends.
+func (s *PostgresStorer) withUserLock(ctx context.Context, lock string, user string, fn func(tx *sqlx.Tx) error) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, tx.Rebind(lock), userLockNamespace, user)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = fn(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPostgresStorer_Put(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewPostgresDb(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1, hashtext\(\$2\)\)`).
+		WithArgs(userLockNamespace, "foo").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO login_events(.|\n)*VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12, \$13, \$14, \$15, \$16, \$17, \$18, \$19, \$20, \$21\)(.|\n)*RETURNING id`).
+		WithArgs("05d86fca-825e-4515-86cc-7775a2d8047e", "foo", int64(1561600005), 27.950575, -82.457176, 50, "10.24.1.22",
+			false, false, false, false, false, false, uint(0), "", "", "geoip", "", "", "", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	record := model.NewRecord("foo", "05d86fca-825e-4515-86cc-7775a2d8047e", 1561600005, "10.24.1.22", model.Anonymity{}, model.Network{}, model.Geo{Lat: 27.950575, Lon: -82.457176, Radius: 50, Source: model.LocationGeoIP})
+	id, err := store.Put(context.Background(), record)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), id)
+	require.Equal(t, int64(7), record.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStorer_PutDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewPostgresDb(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO login_events`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, err = store.Put(context.Background(), &model.Record{EventID: "05d86fca-825e-4515-86cc-7775a2d8047e", UserName: "foo"})
+	require.Equal(t, ErrDuplicateEvent, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStorer_PrecedingAccessWaitsForWriters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewPostgresDb(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock_shared\(\$1, hashtext\(\$2\)\)`).
+		WithArgs(userLockNamespace, "foo").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`(?s)FROM login_events.*\(timestamp, event_uuid\) < \(\$2, \$3\).*ORDER BY timestamp DESC, event_uuid DESC`).
+		WithArgs("foo", int64(500), "e-500").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_uuid", "username", "timestamp"}).AddRow(3, "e-400", "foo", 400))
+	mock.ExpectCommit()
+
+	record, err := store.PrecedingAccess(context.Background(), "foo", 500, "e-500")
+	require.NoError(t, err)
+	require.Equal(t, "e-400", record.EventID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStorer_LockUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewPostgresDb(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1, hashtext\(\$2\)\)`).
+		WithArgs(detectLockNamespace, "foo").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	unlock, err := store.LockUser(context.Background(), "foo")
+	require.NoError(t, err)
+	unlock()
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStorer_LockUserLeavesAConnection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(3)
+	store := NewPostgresDb(db)
+
+	expectLock := func(user string) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_advisory_xact_lock\(`).WithArgs(detectLockNamespace, user).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	expectLock("foo")
+	expectLock("bar")
+	mock.ExpectRollback()
+	expectLock("baz")
+
+	unlockFoo, err := store.LockUser(context.Background(), "foo")
+	require.NoError(t, err)
+	_, err = store.LockUser(context.Background(), "bar")
+	require.NoError(t, err)
+
+	// two of the three connections hold locks, a third lock would leave nothing for the work done under them
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = store.LockUser(ctx, "baz")
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	unlockFoo()
+	_, err = store.LockUser(context.Background(), "baz")
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db.SetMaxOpenConns(1)
+	_, err = NewPostgresDb(db).LockUser(context.Background(), "foo")
+	require.Error(t, err)
+}
+
+//newTestPostgresStorer connects to the database in POSTGRES_TEST_DSN, the tests using it are skipped without one
+func newTestPostgresStorer(t *testing.T) *PostgresStorer {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	_, err = db.Exec(`DROP TABLE IF EXISTS login_events, network_lists, suppressions, alert_history, alerts, schema_version`)
+	require.NoError(t, err)
+	store := NewPostgresDb(db)
+	require.NoError(t, store.Open())
+	return store
+}
+
+func TestPostgresStorer_NearestAccess(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testNearestAccess(t, store)
+}
+
+func TestPostgresStorer_NearestAccessTieBreak(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testNearestAccessTieBreak(t, store)
+}
+
+func TestPostgresStorer_Events(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testEvents(t, store)
+}
+
+func TestPostgresStorer_Users(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testUsers(t, store)
+}
+
+func TestPostgresStorer_Idempotent(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testIdempotent(t, store)
+}
+
+func TestPostgresStorer_NetworkLists(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testNetworkLists(t, store)
+}
+
+func TestPostgresStorer_History(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testHistory(t, store)
+}
+
+func TestPostgresStorer_Alerts(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testAlerts(t, store)
+}
+
+func TestPostgresStorer_AlertLifecycle(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testAlertLifecycle(t, store)
+}
+
+func TestPostgresStorer_ReviseAlert(t *testing.T) {
+	store := newTestPostgresStorer(t)
+	defer store.Close()
+	testReviseAlert(t, store)
+}
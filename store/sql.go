@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/jmoiron/sqlx"
+	"log"
+	"strings"
+)
+
+//the queries shared by the sql backends are written with ? placeholders and rebound for the driver in use
+
+const columns = `id, event_uuid, username, timestamp, lat, lon, radius, ip, verdict,
+anonymity_checked, anonymous, anonymous_vpn, hosting_provider, public_proxy, tor_exit_node, asn, as_org,
+ip_class, location_source, location_label, location_precision, country, time_zone`
+
+const event = `SELECT ` + columns + `
+FROM login_events
+WHERE event_uuid = ?;`
+
+const saveVerdict = `UPDATE login_events
+SET verdict = ?
+WHERE event_uuid = ?;`
+
+//neighbours are ordered by (timestamp, event_uuid), so events sharing a timestamp still have a well defined
+//order and the index on (username, timestamp, event_uuid) can answer both lookups with a single seek.
+const subsequent = `SELECT ` + columns + `
+FROM login_events
+WHERE username = ? AND (timestamp, event_uuid) > (?, ?)
+ORDER BY timestamp ASC, event_uuid ASC
+LIMIT 1;`
+
+const preceeding = `SELECT ` + columns + `
+FROM login_events
+WHERE username = ? AND (timestamp, event_uuid) < (?, ?)
+ORDER BY timestamp DESC, event_uuid DESC
+LIMIT 1;`
+
+const allUsers = `SELECT DISTINCT username
+FROM login_events
+ORDER BY username;`
+
+//sqlStorer implements the parts of the Storer interface that are the same for every sql backend
+type sqlStorer struct {
+	db      *sqlx.DB
+	dialect string
+	// AutoMigrate applies pending migrations when the store is opened, it is on by default
+	AutoMigrate bool
+}
+
+func newSQLStorer(db *sqlx.DB, dialect string) sqlStorer {
+	return sqlStorer{db: db, dialect: dialect, AutoMigrate: true}
+}
+
+//Migrator returns the migrator for this store's schema
+func (s *sqlStorer) Migrator() (*Migrator, error) {
+	return NewMigrator(s.db, s.dialect)
+}
+
+func (s *sqlStorer) migrate() error {
+	if !s.AutoMigrate {
+		return nil
+	}
+	migrator, err := s.Migrator()
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(context.Background())
+	for _, m := range applied {
+		log.Printf("applied migration %04d_%s\n", m.Version, m.Name)
+	}
+	return err
+}
+
+//Event gets the stored event by its event id, or nil if it hasn't been stored
+func (s *sqlStorer) Event(ctx context.Context, eventID string) (*model.Record, error) {
+	return s.get(ctx, s.db, event, eventID)
+}
+
+//SaveVerdict stores the serialized verdict alongside the event
+func (s *sqlStorer) SaveVerdict(ctx context.Context, eventID string, verdict []byte) error {
+	_, err := s.db.ExecContext(ctx, s.db.Rebind(saveVerdict), verdict, eventID)
+	return err
+}
+
+//PrecedingAccess gets the closest access that happened before timestamp for the specified user.
+//Events sharing the timestamp are ordered by event id.
+func (s *sqlStorer) PrecedingAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	return s.get(ctx, s.db, preceeding, user, timestamp, eventID)
+}
+
+//SubsequentAccess gets the closest access that happened after timestamp for the specified user.
+//Events sharing the timestamp are ordered by event id.
+func (s *sqlStorer) SubsequentAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error) {
+	return s.get(ctx, s.db, subsequent, user, timestamp, eventID)
+}
+
+//Users gets every user with a stored event
+func (s *sqlStorer) Users(ctx context.Context) ([]string, error) {
+	var users []string
+	err := s.db.SelectContext(ctx, &users, allUsers)
+	return users, err
+}
+
+//Events gets the user's events matching the filter, in (timestamp, event id) order
+func (s *sqlStorer) Events(ctx context.Context, user string, filter model.EventFilter) ([]*model.Record, error) {
+	conditions := []string{"username = ?"}
+	args := []interface{}{user}
+	if filter.From != nil {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, *filter.To)
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(timestamp, event_uuid) > (?, ?)")
+		args = append(args, filter.After.Timestamp, filter.After.EventID)
+	}
+
+	query := `SELECT ` + columns + `
+FROM login_events
+WHERE ` + strings.Join(conditions, " AND ") + `
+ORDER BY timestamp ASC, event_uuid ASC`
+	if filter.Limit > 0 {
+		query += `
+LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	var records []*model.Record
+	err := s.db.SelectContext(ctx, &records, s.db.Rebind(query), args...)
+	return records, err
+}
+
+func (s *sqlStorer) get(ctx context.Context, q sqlx.QueryerContext, query string, args ...interface{}) (*model.Record, error) {
+	record := &model.Record{}
+	err := sqlx.GetContext(ctx, q, record, s.db.Rebind(query), args...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -9,54 +9,28 @@ import (
 	"log"
 )
 
-var schema = `create table if not exists events
-(
-	id INTEGER
-		constraint events_pk
-			primary key autoincrement,
-	username text,
-	timestamp int,
-	lat real,
-	lon real,
-	radius int,
-	ip text,
-	anonymous boolean,
-	constraint events_pk_2
-		unique (username, ip)
-);`
-
-
-const insert = `INSERT INTO events(username, timestamp, lat, lon, radius, ip, anonymous)
-VALUES (?, ?, ?, ?, ?, ?, ?)
-ON CONFLICT(username,ip) DO UPDATE SET timestamp=excluded.timestamp
-                                       WHERE excluded.timestamp > events.timestamp;`
-const subsequent = `SELECT *
-FROM events
-WHERE username = ? AND timestamp > ?
-LIMIT 1;`
-
-const preceeding = `SELECT *
-FROM events
-WHERE username = ? AND timestamp < ?
-LIMIT 1;`
+const insert = `INSERT INTO login_events(event_uuid, username, timestamp, lat, lon, radius, ip,
+	anonymity_checked, anonymous, anonymous_vpn, hosting_provider, public_proxy, tor_exit_node, asn, as_org,
+	ip_class, location_source, location_label, location_precision, country, time_zone)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(event_uuid) DO NOTHING;`
 
 //SqliteStorer satisfies the Storer interface, but is specific to Sqlite
 type SqliteStorer struct {
-	db *sqlx.DB
+	sqlStorer
 }
 
 //NewSqliteDb is a constructor that takes a *sql.DB, this is so you can modify the driver before it gets here.
 //For example you could decorate the driver with an OpenTracing db driver.
 func NewSqliteDb(db *sql.DB) *SqliteStorer {
-	return &SqliteStorer{db: sqlx.NewDb(db, "sqlite3")}
+	return &SqliteStorer{newSQLStorer(sqlx.NewDb(db, "sqlite3"), "sqlite")}
 }
 
-//Open is a very cheap way to run migrations, normally you'd just ping the database
+//Open applies any pending migrations, unless AutoMigrate has been turned off. Normally you'd also ping the database,
 //maybe even have an exponential backoff, in case it takes a little bit to establish connection
 func (s *SqliteStorer) Open() error {
 	log.Println("opening sqlite store")
-	_, err := s.db.Exec(schema)
-	return err
+	return s.migrate()
 }
 
 //Close closes the underlying db
@@ -69,37 +43,63 @@ func (s *SqliteStorer) Close() error {
 	return nil
 }
 
-//Put will store the user login event into the database, it will also update the record model with the ID that was inserted
+//Put will store the user login event into the database, it will also update the record model with the ID that was inserted.
+//If the event id has been stored before nothing is written and ErrDuplicateEvent is returned.
 func (s *SqliteStorer) Put(ctx context.Context, record *model.Record) (int64, error) {
-	result, err := s.db.ExecContext(ctx, insert, record.UserName, record.Timestamp, record.Lat, record.Lon, record.Radius, record.IP, record.Anonymous)
+	result, err := s.db.ExecContext(ctx, insert, record.EventID, record.UserName, record.Timestamp, record.Lat, record.Lon, record.Radius, record.IP,
+		record.Checked, record.Anonymous, record.VPN, record.Hosting, record.PublicProxy, record.TorExitNode,
+		record.ASN, record.Organization, record.IPClass, record.Source, record.Label, record.Precision,
+		record.Country, record.TimeZone)
 	if err != nil {
 		return 0, err
 	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, ErrDuplicateEvent
+	}
 	id, err := result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
+	record.ID = id
 	return id, nil
 }
 
-//PrecedingAccess gets the access that happened before timestamp for the specified user
-func (s *SqliteStorer) PrecedingAccess(ctx context.Context, user string, timestamp int64) (*model.Record, error) {
-	return s.getAccess(ctx, user, timestamp, preceeding)
-}
-
-//SubsequentAccess gets the access that happened after timestamp for the specified user
-func (s *SqliteStorer) SubsequentAccess(ctx context.Context, user string, timestamp int64) (*model.Record, error) {
-	return s.getAccess(ctx, user, timestamp, subsequent)
+//PutAlert stores the alert, it will also update the alert with the ID that was inserted. If the event already has
+//an alert nothing is written and ErrDuplicateAlert is returned.
+func (s *SqliteStorer) PutAlert(ctx context.Context, alert *model.Alert) (int64, error) {
+	result, err := s.db.ExecContext(ctx, insertAlert, alertArgs(alert)...)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if affected == 0 {
+		return 0, ErrDuplicateAlert
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	alert.ID = id
+	return id, nil
 }
 
-func (s *SqliteStorer) getAccess(ctx context.Context, user string, timestamp int64, query string) (*model.Record, error) {
-	record := &model.Record{}
-	err := s.db.GetContext(ctx, record, query, user, timestamp)
-	if err == sql.ErrNoRows {
-		return nil, nil
+//PutNetwork adds an entry to the allow or deny list, it will also update the entry with the ID that was inserted
+func (s *SqliteStorer) PutNetwork(ctx context.Context, entry *model.NetworkListEntry) (int64, error) {
+	result, err := s.db.ExecContext(ctx, insertNetwork, entry.List, entry.CIDR, entry.UserName, entry.Comment, entry.CreatedAt)
+	if err != nil {
+		return 0, err
 	}
+	id, err := result.LastInsertId()
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return record, nil
+	entry.ID = id
+	return id, nil
 }
@@ -2,10 +2,11 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/edwardsb/secureworks/model"
-	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 	"time"
 )
@@ -14,27 +15,141 @@ func TestSqliteStorer_Put(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
-	store := SqliteStorer{db: sqlx.NewDb(db, "sqlite3")}
+	store := NewSqliteDb(db)
 
 	timestamp := time.Now().Unix()
-	mock.ExpectExec("INSERT INTO events").
-		WithArgs("foo", timestamp, 27.950575, -82.457176, 50, "10.24.1.22", false).
+	mock.ExpectExec("INSERT INTO login_events").
+		WithArgs("05d86fca-825e-4515-86cc-7775a2d8047e", "foo", timestamp, 27.950575, -82.457176, 50, "10.24.1.22",
+			true, true, true, false, false, false, uint(6128), "Cablevision Systems Corp.",
+			"public", "geoip", "", "city", "US", "America/New_York").
 		WillReturnResult(sqlmock.NewResult(int64(12), 1))
 
 	record := &model.Record{
+		EventID:   "05d86fca-825e-4515-86cc-7775a2d8047e",
 		UserName:  "foo",
 		Timestamp: timestamp,
 		IP:        "10.24.1.22",
-		Anonymous: false,
+		Anonymity: model.Anonymity{Checked: true, Anonymous: true, VPN: true},
+		Network:   model.Network{ASN: 6128, Organization: "Cablevision Systems Corp."},
 		Geo: model.Geo{
-			Lat:    27.950575,
-			Lon:    -82.457176,
-			Radius: 50,
+			Lat:       27.950575,
+			Lon:       -82.457176,
+			Radius:    50,
+			Source:    model.LocationGeoIP,
+			Precision: "city",
+			Country:   "US",
+			TimeZone:  "America/New_York",
 		},
+		IPClass: "public",
 	}
 	id, err := store.Put(context.Background(), record)
 	require.NoError(t, err)
 	err = mock.ExpectationsWereMet()
 	require.NoError(t, err)
 	require.Equal(t, id, int64(12))
+	require.Equal(t, record.ID, int64(12))
+}
+
+func TestSqliteStorer_PutDuplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewSqliteDb(db)
+
+	mock.ExpectExec("INSERT INTO login_events").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, err = store.Put(context.Background(), &model.Record{EventID: "05d86fca-825e-4515-86cc-7775a2d8047e"})
+	require.Equal(t, ErrDuplicateEvent, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newTestSqliteStorer(t *testing.T) *SqliteStorer {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	// every connection to :memory: is its own database, so stick to one
+	db.SetMaxOpenConns(1)
+	store := NewSqliteDb(db)
+	require.NoError(t, store.Open())
+	return store
+}
+
+func TestSqliteStorer_NearestAccessUsesIndex(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+
+	for _, query := range []string{preceeding, subsequent} {
+		rows, err := store.db.Queryx("EXPLAIN QUERY PLAN "+query, "foo", 500, "b")
+		require.NoError(t, err)
+		var plan []string
+		for rows.Next() {
+			var id, parent, notUsed int
+			var detail string
+			require.NoError(t, rows.Scan(&id, &parent, &notUsed, &detail))
+			plan = append(plan, detail)
+		}
+		require.NoError(t, rows.Close())
+		require.Contains(t, strings.Join(plan, "\n"), "login_events_username_timestamp")
+		require.NotContains(t, strings.Join(plan, "\n"), "TEMP B-TREE")
+	}
+}
+
+func TestSqliteStorer_NearestAccess(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testNearestAccess(t, store)
+}
+
+func TestSqliteStorer_NearestAccessTieBreak(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testNearestAccessTieBreak(t, store)
+}
+
+func TestSqliteStorer_Events(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testEvents(t, store)
+}
+
+func TestSqliteStorer_Users(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testUsers(t, store)
+}
+
+func TestSqliteStorer_Idempotent(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testIdempotent(t, store)
+}
+
+func TestSqliteStorer_NetworkLists(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testNetworkLists(t, store)
+}
+
+func TestSqliteStorer_History(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testHistory(t, store)
+}
+
+func TestSqliteStorer_Alerts(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testAlerts(t, store)
+}
+
+func TestSqliteStorer_AlertLifecycle(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testAlertLifecycle(t, store)
+}
+
+func TestSqliteStorer_ReviseAlert(t *testing.T) {
+	store := newTestSqliteStorer(t)
+	defer store.Close()
+	testReviseAlert(t, store)
 }
@@ -2,12 +2,79 @@ package store
 
 import (
 	"context"
+	"errors"
 	"github.com/edwardsb/secureworks/model"
 )
 
-//Storer is responsible for writing new events to the data store, and retrieving preceding and subsequent access
+//ErrDuplicateEvent is returned by Put when an event with the same event id has already been stored
+var ErrDuplicateEvent = errors.New("event already stored")
+
+//ErrNotFound is returned when updating or deleting something that isn't in the store
+var ErrNotFound = errors.New("not found")
+
+//ErrDuplicateAlert is returned by PutAlert when the event already has an alert
+var ErrDuplicateAlert = errors.New("alert already raised for event")
+
+//Storer is responsible for writing new events to the data store, and retrieving preceding and subsequent access.
+//Events are never updated or collapsed, the only thing that can change after Put is the stored verdict.
+//
+//A user's events are ordered by (timestamp, event id), so events that share a timestamp are still strictly ordered.
+//PrecedingAccess and SubsequentAccess return the nearest event before or after the given position in that order,
+//or nil when there isn't one. Events lists the user's events in that order.
 type Storer interface {
 	Put(ctx context.Context, record *model.Record) (int64, error)
-	PrecedingAccess(ctx context.Context, user string, timestamp int64) (*model.Record, error)
-	SubsequentAccess(ctx context.Context, user string, timestamp int64) (*model.Record, error)
+	Event(ctx context.Context, eventID string) (*model.Record, error)
+	SaveVerdict(ctx context.Context, eventID string, verdict []byte) error
+	PrecedingAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error)
+	SubsequentAccess(ctx context.Context, user string, timestamp int64, eventID string) (*model.Record, error)
+	Events(ctx context.Context, user string, filter model.EventFilter) ([]*model.Record, error)
+}
+
+//NetworkStorer keeps the CIDR allow and deny lists. It is optional, a store that doesn't implement it has no lists.
+//
+//Networks returns the entries that apply to the user, which are the global entries plus the user's own.
+//AllNetworks returns every entry. Both are ordered by id.
+type NetworkStorer interface {
+	PutNetwork(ctx context.Context, entry *model.NetworkListEntry) (int64, error)
+	DeleteNetwork(ctx context.Context, id int64) error
+	Networks(ctx context.Context, user string) ([]*model.NetworkListEntry, error)
+	AllNetworks(ctx context.Context) ([]*model.NetworkListEntry, error)
+}
+
+//AlertStorer keeps the alerts raised for suspicious events, at most one per event. It is optional, a store that
+//doesn't implement it doesn't keep alerts.
+//
+//Alerts are worked with UpdateAlert, which records every change in the alert's history. History is only ever added
+//to. Marking an alert a false positive adds suppressions for the user between the locations of the legs of travel it
+//was raised for, which Suppressions returns for the detector. ReviseAlert keeps an alert that is still being worked in line with its
+//event's verdict when the event is evaluated again.
+type AlertStorer interface {
+	PutAlert(ctx context.Context, alert *model.Alert) (int64, error)
+	ReviseAlert(ctx context.Context, alert *model.Alert, now int64) (*model.Alert, error)
+	Alert(ctx context.Context, id int64) (*model.Alert, error)
+	Alerts(ctx context.Context, filter model.AlertFilter) ([]*model.Alert, error)
+	UpdateAlert(ctx context.Context, id int64, update *model.AlertUpdate, now int64) (*model.Alert, error)
+	Suppressions(ctx context.Context, user string) ([]*model.Suppression, error)
+}
+
+//UserLister lists every user with a stored event, in order. It is optional, without it the users to work on have to
+//be named.
+type UserLister interface {
+	Users(ctx context.Context) ([]string, error)
+}
+
+//UserLocker serializes work on a user's events across every process sharing the store. It is optional, the detector
+//always serializes per user within its own process. LockUser blocks until the lock is held, calling unlock
+//releases it.
+type UserLocker interface {
+	LockUser(ctx context.Context, user string) (unlock func(), err error)
+}
+
+//HistoryStorer answers whether a user has been seen somewhere before. It is optional, without it the risk signals
+//that depend on the user's history aren't evaluated.
+//
+//Only events before the given position, in the same (timestamp, event id) order as the neighbour lookups, count.
+type HistoryStorer interface {
+	SeenCountry(ctx context.Context, user string, country string, timestamp int64, eventID string) (bool, error)
+	SeenASN(ctx context.Context, user string, asn uint, timestamp int64, eventID string) (bool, error)
 }
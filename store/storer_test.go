@@ -0,0 +1,450 @@
+package store
+
+import (
+	"context"
+	"github.com/edwardsb/secureworks/model"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+//behaviour every Storer implementation must share, each backend's tests run these against a real or fake backend
+
+func putRecord(t *testing.T, store Storer, user, eventID string, timestamp int64) {
+	_, err := store.Put(context.Background(), &model.Record{EventID: eventID, UserName: user, Timestamp: timestamp, IP: "10.24.1.22"})
+	require.NoError(t, err)
+}
+
+func testNearestAccess(t *testing.T, store Storer) {
+	ctx := context.Background()
+
+	// inserted out of order so insertion order can't be mistaken for time order
+	putRecord(t, store, "foo", "e-500", 500)
+	putRecord(t, store, "foo", "e-100", 100)
+	putRecord(t, store, "foo", "e-900", 900)
+	putRecord(t, store, "foo", "e-400", 400)
+	putRecord(t, store, "foo", "e-600", 600)
+	putRecord(t, store, "bar", "b-450", 450)
+	putRecord(t, store, "foo", "e-neg", -50)
+
+	preceding, err := store.PrecedingAccess(ctx, "foo", 500, "e-500")
+	require.NoError(t, err)
+	require.Equal(t, "e-400", preceding.EventID)
+
+	subsequent, err := store.SubsequentAccess(ctx, "foo", 500, "e-500")
+	require.NoError(t, err)
+	require.Equal(t, "e-600", subsequent.EventID)
+
+	preceding, err = store.PrecedingAccess(ctx, "foo", 100, "e-100")
+	require.NoError(t, err)
+	require.Equal(t, "e-neg", preceding.EventID)
+
+	preceding, err = store.PrecedingAccess(ctx, "foo", -50, "e-neg")
+	require.NoError(t, err)
+	require.Nil(t, preceding)
+
+	subsequent, err = store.SubsequentAccess(ctx, "foo", 900, "e-900")
+	require.NoError(t, err)
+	require.Nil(t, subsequent)
+}
+
+func testNearestAccessTieBreak(t *testing.T, store Storer) {
+	ctx := context.Background()
+
+	putRecord(t, store, "foo", "c", 500)
+	putRecord(t, store, "foo", "a", 500)
+	putRecord(t, store, "foo", "b", 500)
+	putRecord(t, store, "foo", "z", 400)
+
+	preceding, err := store.PrecedingAccess(ctx, "foo", 500, "b")
+	require.NoError(t, err)
+	require.Equal(t, "a", preceding.EventID)
+
+	subsequent, err := store.SubsequentAccess(ctx, "foo", 500, "b")
+	require.NoError(t, err)
+	require.Equal(t, "c", subsequent.EventID)
+
+	preceding, err = store.PrecedingAccess(ctx, "foo", 500, "a")
+	require.NoError(t, err)
+	require.Equal(t, "z", preceding.EventID)
+
+	subsequent, err = store.SubsequentAccess(ctx, "foo", 500, "c")
+	require.NoError(t, err)
+	require.Nil(t, subsequent)
+}
+
+func testEvents(t *testing.T, store Storer) {
+	ctx := context.Background()
+
+	// inserted out of order, with a tie on 400 that the event id breaks
+	putRecord(t, store, "foo", "e-500", 500)
+	putRecord(t, store, "foo", "e-100", 100)
+	putRecord(t, store, "foo", "e-400b", 400)
+	putRecord(t, store, "foo", "e-900", 900)
+	putRecord(t, store, "foo", "e-400a", 400)
+	putRecord(t, store, "bar", "b-450", 450)
+	putRecord(t, store, "foo", "e-neg", -50)
+
+	eventIDs := func(filter model.EventFilter) []string {
+		records, err := store.Events(ctx, "foo", filter)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, r := range records {
+			ids = append(ids, r.EventID)
+		}
+		return ids
+	}
+	from, to := int64(100), int64(500)
+	require.Equal(t, []string{"e-neg", "e-100", "e-400a", "e-400b", "e-500", "e-900"}, eventIDs(model.EventFilter{}))
+	require.Equal(t, []string{"e-100", "e-400a", "e-400b"}, eventIDs(model.EventFilter{From: &from, To: &to}))
+	require.Equal(t, []string{"e-neg", "e-100", "e-400a", "e-400b"}, eventIDs(model.EventFilter{To: &to}))
+	require.Equal(t, []string{"e-100", "e-400a"}, eventIDs(model.EventFilter{From: &from, Limit: 2}))
+	require.Equal(t, []string{"e-400b", "e-500"}, eventIDs(model.EventFilter{From: &from, Limit: 2,
+		After: &model.EventPosition{Timestamp: 400, EventID: "e-400a"}}))
+	// a cursor before from doesn't widen the range, and one past to leaves nothing
+	require.Equal(t, []string{"e-100", "e-400a", "e-400b"}, eventIDs(model.EventFilter{From: &from, To: &to,
+		After: &model.EventPosition{Timestamp: -50, EventID: "e-neg"}}))
+	require.Equal(t, []string{}, eventIDs(model.EventFilter{From: &from, To: &to,
+		After: &model.EventPosition{Timestamp: 900, EventID: "e-900"}}))
+
+	records, err := store.Events(ctx, "nobody", model.EventFilter{})
+	require.NoError(t, err)
+	require.Empty(t, records)
+}
+
+func testUsers(t *testing.T, store interface {
+	Storer
+	UserLister
+}) {
+	users, err := store.Users(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	putRecord(t, store, "foo", "e-100", 100)
+	putRecord(t, store, "bar", "b-100", 100)
+	putRecord(t, store, "foo", "e-200", 200)
+	users, err = store.Users(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"bar", "foo"}, users)
+}
+
+func testIdempotent(t *testing.T, store Storer) {
+	ctx := context.Background()
+
+	putRecord(t, store, "foo", "e-500", 500)
+	_, err := store.Put(ctx, &model.Record{EventID: "e-500", UserName: "foo", Timestamp: 700})
+	require.Equal(t, ErrDuplicateEvent, err)
+
+	require.NoError(t, store.SaveVerdict(ctx, "e-500", []byte(`{"findings":[]}`)))
+	record, err := store.Event(ctx, "e-500")
+	require.NoError(t, err)
+	require.Equal(t, int64(500), record.Timestamp)
+	require.Equal(t, `{"findings":[]}`, string(record.Verdict))
+
+	record, err = store.Event(ctx, "missing")
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	anonymity := model.Anonymity{Checked: true, Anonymous: true, TorExitNode: true}
+	network := model.Network{ASN: 6128, Organization: "Cablevision Systems Corp."}
+	_, err = store.Put(ctx, &model.Record{EventID: "e-600", UserName: "foo", Timestamp: 600, IP: "2001:db8::1", IPClass: "reserved",
+		Anonymity: anonymity, Network: network, Geo: model.Geo{Source: model.LocationTrustedNetwork, Label: "atlanta office"}})
+	require.NoError(t, err)
+	record, err = store.Event(ctx, "e-600")
+	require.NoError(t, err)
+	require.Equal(t, anonymity, record.Anonymity)
+	require.Equal(t, network, record.Network)
+	require.Equal(t, "2001:db8::1", record.IP)
+	require.Equal(t, "reserved", record.IPClass)
+	require.Equal(t, model.LocationTrustedNetwork, record.Source)
+	require.Equal(t, "atlanta office", record.Label)
+
+	_, err = store.Put(ctx, &model.Record{EventID: "e-700", UserName: "foo", Timestamp: 700, IP: "68.193.88.103",
+		Geo: model.Geo{Lat: 37.751, Lon: -97.822, Radius: 1000, Source: model.LocationGeoIP, Precision: "centroid",
+			Country: "US", TimeZone: "America/Chicago"}})
+	require.NoError(t, err)
+	record, err = store.Event(ctx, "e-700")
+	require.NoError(t, err)
+	require.Equal(t, "centroid", record.Precision)
+	require.Equal(t, "US", record.Country)
+	require.Equal(t, "America/Chicago", record.TimeZone)
+}
+
+func testHistory(t *testing.T, store interface {
+	Storer
+	HistoryStorer
+}) {
+	ctx := context.Background()
+	for _, record := range []*model.Record{
+		{EventID: "e-100", UserName: "foo", Timestamp: 100, Network: model.Network{ASN: 6128}, Geo: model.Geo{Country: "US"}},
+		{EventID: "e-200", UserName: "foo", Timestamp: 200, Network: model.Network{ASN: 3320}, Geo: model.Geo{Country: "DE"}},
+		{EventID: "e-150", UserName: "bar", Timestamp: 150, Network: model.Network{ASN: 2856}, Geo: model.Geo{Country: "GB"}},
+	} {
+		_, err := store.Put(ctx, record)
+		require.NoError(t, err)
+	}
+
+	seen, err := store.SeenCountry(ctx, "foo", "US", 200, "e-200")
+	require.NoError(t, err)
+	require.True(t, seen)
+	// only earlier events count
+	seen, err = store.SeenCountry(ctx, "foo", "DE", 200, "e-200")
+	require.NoError(t, err)
+	require.False(t, seen)
+	seen, err = store.SeenCountry(ctx, "foo", "GB", 300, "e-300")
+	require.NoError(t, err)
+	require.False(t, seen)
+
+	seen, err = store.SeenASN(ctx, "foo", 6128, 200, "e-200")
+	require.NoError(t, err)
+	require.True(t, seen)
+	seen, err = store.SeenASN(ctx, "foo", 3320, 100, "e-100")
+	require.NoError(t, err)
+	require.False(t, seen)
+	seen, err = store.SeenASN(ctx, "bar", 6128, 300, "e-300")
+	require.NoError(t, err)
+	require.False(t, seen)
+}
+
+func testNetworkLists(t *testing.T, store NetworkStorer) {
+	ctx := context.Background()
+
+	entries := []*model.NetworkListEntry{
+		{List: model.AllowList, CIDR: "10.0.0.0/8", Comment: "corporate", CreatedAt: 1561600005},
+		{List: model.DenyList, CIDR: "198.51.100.0/24", UserName: "foo", CreatedAt: 1561600006},
+		{List: model.AllowList, CIDR: "2001:db8::/32", UserName: "bar", CreatedAt: 1561600007},
+	}
+	for _, entry := range entries {
+		id, err := store.PutNetwork(ctx, entry)
+		require.NoError(t, err)
+		require.Equal(t, id, entry.ID)
+	}
+
+	all, err := store.AllNetworks(ctx)
+	require.NoError(t, err)
+	require.Equal(t, entries, all)
+
+	foo, err := store.Networks(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, entries[:2], foo)
+
+	require.NoError(t, store.DeleteNetwork(ctx, entries[0].ID))
+	require.Equal(t, ErrNotFound, store.DeleteNetwork(ctx, entries[0].ID))
+
+	foo, err = store.Networks(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, entries[1:2], foo)
+}
+
+func testAlerts(t *testing.T, store AlertStorer) {
+	ctx := context.Background()
+	reasons := model.Reasons{{Code: "impossible_travel", Rule: "impossible_travel", Leg: "preceding", Triggered: true,
+		Travel: &model.Travel{DistanceKm: 3935, RequiredSpeedKmh: 3935, LimitKmh: 732}}}
+	for i, alert := range []*model.Alert{
+		{EventID: "e-100", UserName: "foo", EventTimestamp: 100, Score: 80, Severity: model.SeverityHigh},
+		{EventID: "e-200", UserName: "foo", EventTimestamp: 200, Score: 30, Severity: model.SeverityLow},
+		{EventID: "e-300", UserName: "bar", EventTimestamp: 300, Score: 100, Severity: model.SeverityCritical},
+		{EventID: "e-400", UserName: "foo", EventTimestamp: 400, Score: 90, Severity: model.SeverityCritical, Status: "resolved"},
+	} {
+		if alert.Status == "" {
+			alert.Status = model.AlertOpen
+		}
+		alert.PrecedingEventID = "e-0"
+		alert.Reasons = reasons
+		alert.CreatedAt = int64(1000 + i)
+		id, err := store.PutAlert(ctx, alert)
+		require.NoError(t, err)
+		require.Equal(t, id, alert.ID)
+	}
+	_, err := store.PutAlert(ctx, &model.Alert{EventID: "e-100", UserName: "foo", Reasons: reasons})
+	require.Equal(t, ErrDuplicateAlert, err)
+
+	eventIDs := func(filter model.AlertFilter) []string {
+		alerts, err := store.Alerts(ctx, filter)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, a := range alerts {
+			ids = append(ids, a.EventID)
+		}
+		return ids
+	}
+	require.Equal(t, []string{"e-400", "e-300", "e-200", "e-100"}, eventIDs(model.AlertFilter{}))
+	require.Equal(t, []string{"e-400", "e-200", "e-100"}, eventIDs(model.AlertFilter{UserName: "foo"}))
+	from, to := int64(200), int64(400)
+	require.Equal(t, []string{"e-300", "e-200"}, eventIDs(model.AlertFilter{From: &from, To: &to}))
+	require.Equal(t, []string{"e-400", "e-300", "e-100"}, eventIDs(model.AlertFilter{Severities: []string{"high", "critical"}}))
+	require.Equal(t, []string{"e-300", "e-200", "e-100"}, eventIDs(model.AlertFilter{Statuses: []string{model.AlertOpen}}))
+	require.Equal(t, []string{"e-400", "e-100"}, eventIDs(model.AlertFilter{UserName: "foo", Severities: []string{"high", "critical"}}))
+
+	// a page at a time
+	page := eventIDs(model.AlertFilter{Limit: 2})
+	require.Equal(t, []string{"e-400", "e-300"}, page)
+	alerts, err := store.Alerts(ctx, model.AlertFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"e-200", "e-100"}, eventIDs(model.AlertFilter{Limit: 2, Before: alerts[1].ID}))
+
+	alerts, err = store.Alerts(ctx, model.AlertFilter{UserName: "bar"})
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, reasons, alerts[0].Reasons)
+	require.Equal(t, "e-0", alerts[0].PrecedingEventID)
+	require.Equal(t, int64(300), alerts[0].EventTimestamp)
+	require.Equal(t, int64(1002), alerts[0].CreatedAt)
+	require.Equal(t, 100, alerts[0].Score)
+}
+
+func testAlertLifecycle(t *testing.T, store interface {
+	Storer
+	AlertStorer
+}) {
+	ctx := context.Background()
+	travel := func(leg string, triggered bool) model.Reasons {
+		return model.Reasons{{Code: "impossible_travel", Rule: "impossible_travel", Leg: leg, Triggered: triggered}}
+	}
+	for _, a := range []struct {
+		record                *model.Record
+		preceding, subsequent string
+		reasons               model.Reasons
+	}{
+		{&model.Record{EventID: "e-100", UserName: "foo", Timestamp: 100, Geo: model.Geo{Lat: 34.0522, Lon: -118.2437, Radius: 200, Source: model.LocationGeoIP}},
+			"", "e-200", travel("subsequent", true)},
+		{&model.Record{EventID: "e-200", UserName: "foo", Timestamp: 200, Geo: model.Geo{Lat: 40.7128, Lon: -74.0060, Radius: 5, Source: model.LocationGeoIP}},
+			"e-100", "e-300", append(travel("preceding", true), travel("subsequent", false)...)},
+		{&model.Record{EventID: "e-300", UserName: "foo", Timestamp: 300, Geo: model.Geo{Source: model.LocationUnknown}},
+			"e-200", "", travel("preceding", true)},
+	} {
+		_, err := store.Put(ctx, a.record)
+		require.NoError(t, err)
+		_, err = store.PutAlert(ctx, &model.Alert{EventID: a.record.EventID, UserName: "foo", EventTimestamp: a.record.Timestamp,
+			PrecedingEventID: a.preceding, SubsequentEventID: a.subsequent, Reasons: a.reasons, Status: model.AlertOpen, CreatedAt: 1000})
+		require.NoError(t, err)
+	}
+	status := func(s string) *string {
+		return &s
+	}
+
+	alert, err := store.Alert(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "e-100", alert.EventID)
+	require.Empty(t, alert.History)
+	alert, err = store.Alert(ctx, 99)
+	require.NoError(t, err)
+	require.Nil(t, alert)
+	_, err = store.UpdateAlert(ctx, 99, &model.AlertUpdate{Comment: "?", Actor: "alice"}, 2000)
+	require.Equal(t, ErrNotFound, err)
+
+	alert, err = store.UpdateAlert(ctx, 1, &model.AlertUpdate{Status: status(model.AlertAcknowledged), Assignee: status("alice"), Actor: "alice"}, 2000)
+	require.NoError(t, err)
+	require.Equal(t, model.AlertAcknowledged, alert.Status)
+	require.Equal(t, "alice", alert.Assignee)
+	require.Equal(t, int64(2000), alert.UpdatedAt)
+	alert, err = store.UpdateAlert(ctx, 1, &model.AlertUpdate{Comment: "travelling", Actor: "alice"}, 2100)
+	require.NoError(t, err)
+	require.Equal(t, model.AlertAcknowledged, alert.Status)
+	require.Equal(t, "alice", alert.Assignee)
+	alert, err = store.UpdateAlert(ctx, 1, &model.AlertUpdate{Status: status(model.AlertFalsePositive), Assignee: status(""), Actor: "bob"}, 2200)
+	require.NoError(t, err)
+	require.Equal(t, []*model.AlertChange{
+		{ID: 1, AlertID: 1, Actor: "alice", PreviousStatus: "open", Status: "acknowledged", Assignee: "alice", CreatedAt: 2000},
+		{ID: 2, AlertID: 1, Actor: "alice", PreviousStatus: "acknowledged", Status: "acknowledged", PreviousAssignee: "alice", Assignee: "alice", Comment: "travelling", CreatedAt: 2100},
+		{ID: 3, AlertID: 1, Actor: "bob", PreviousStatus: "acknowledged", Status: "false_positive", PreviousAssignee: "alice", CreatedAt: 2200},
+	}, alert.History)
+
+	// false positives suppress the pair of locations of each leg that triggered, with at least the minimum radius,
+	// legs that didn't trigger or have an end in an unknown location aren't suppressed
+	for _, id := range []int64{2, 3} {
+		_, err = store.UpdateAlert(ctx, id, &model.AlertUpdate{Status: status(model.AlertFalsePositive), Actor: "bob"}, 2300)
+		require.NoError(t, err)
+	}
+	suppressions, err := store.Suppressions(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, []*model.Suppression{
+		{ID: suppressions[0].ID, AlertID: 1, Leg: "subsequent", UserName: "foo", Lat: 34.0522, Lon: -118.2437, Radius: 200,
+			NeighbourLat: 40.7128, NeighbourLon: -74.0060, NeighbourRadius: model.MinSuppressionRadiusKm, CreatedAt: 2200},
+		{ID: suppressions[1].ID, AlertID: 2, Leg: "preceding", UserName: "foo", Lat: 40.7128, Lon: -74.0060, Radius: model.MinSuppressionRadiusKm,
+			NeighbourLat: 34.0522, NeighbourLon: -118.2437, NeighbourRadius: 200, CreatedAt: 2300},
+	}, suppressions)
+	suppressions, err = store.Suppressions(ctx, "bar")
+	require.NoError(t, err)
+	require.Empty(t, suppressions)
+
+	// moving on from false positive lifts the suppression
+	_, err = store.UpdateAlert(ctx, 1, &model.AlertUpdate{Status: status(model.AlertOpen), Actor: "bob"}, 2400)
+	require.NoError(t, err)
+	suppressions, err = store.Suppressions(ctx, "foo")
+	require.NoError(t, err)
+	require.Len(t, suppressions, 1)
+	require.Equal(t, int64(2), suppressions[0].AlertID)
+
+	alerts, err := store.Alerts(ctx, model.AlertFilter{Statuses: []string{model.AlertFalsePositive}})
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+}
+
+func testReviseAlert(t *testing.T, store AlertStorer) {
+	ctx := context.Background()
+	reasons := model.Reasons{{Code: "impossible_travel", Rule: "impossible_travel", Leg: "preceding", Triggered: true}}
+	for _, eventID := range []string{"e-100", "e-200", "e-300"} {
+		_, err := store.PutAlert(ctx, &model.Alert{EventID: eventID, UserName: "foo", PrecedingEventID: "e-0", Score: 70,
+			Severity: model.SeverityHigh, Status: model.AlertOpen, Reasons: reasons, CreatedAt: 1000})
+		require.NoError(t, err)
+	}
+	status := func(s string) *string {
+		return &s
+	}
+	_, err := store.UpdateAlert(ctx, 2, &model.AlertUpdate{Status: status(model.AlertAcknowledged), Assignee: status("alice"), Actor: "alice"}, 1100)
+	require.NoError(t, err)
+	_, err = store.UpdateAlert(ctx, 3, &model.AlertUpdate{Status: status(model.AlertFalsePositive), Actor: "alice"}, 1100)
+	require.NoError(t, err)
+
+	// nothing changed, nothing to revise
+	revised, err := store.ReviseAlert(ctx, &model.Alert{EventID: "e-100", PrecedingEventID: "e-0", Score: 70,
+		Severity: model.SeverityHigh, Status: model.AlertOpen, Reasons: reasons}, 2000)
+	require.NoError(t, err)
+	require.Nil(t, revised)
+
+	revised, err = store.ReviseAlert(ctx, &model.Alert{EventID: "e-100", PrecedingEventID: "e-50", SubsequentEventID: "e-150",
+		Score: 100, Severity: model.SeverityCritical, Status: model.AlertOpen, Reasons: reasons}, 2100)
+	require.NoError(t, err)
+	require.Equal(t, "e-50", revised.PrecedingEventID)
+	require.Equal(t, "e-150", revised.SubsequentEventID)
+	require.Equal(t, 100, revised.Score)
+	require.Equal(t, model.SeverityCritical, revised.Severity)
+	require.Equal(t, model.AlertOpen, revised.Status)
+	require.Equal(t, int64(2100), revised.UpdatedAt)
+	require.Equal(t, []*model.AlertChange{{ID: revised.History[0].ID, AlertID: 1, Actor: model.AlertActorDetector,
+		PreviousStatus: model.AlertOpen, Status: model.AlertOpen,
+		Comment: "evaluated again, the score went from 70 to 100, it was judged against different neighbours", CreatedAt: 2100}},
+		revised.History)
+
+	// the same score, only the reasons changed
+	revised, err = store.ReviseAlert(ctx, &model.Alert{EventID: "e-100", PrecedingEventID: "e-50", SubsequentEventID: "e-150",
+		Score: 100, Severity: model.SeverityCritical, Status: model.AlertOpen, Reasons: append(reasons, model.Reason{
+			Code: "impossible_travel", Rule: "impossible_travel", Leg: "subsequent", Triggered: true})}, 2150)
+	require.NoError(t, err)
+	require.Len(t, revised.Reasons, 2)
+	require.Equal(t, "evaluated again, the reasons changed", revised.History[1].Comment)
+
+	// no longer suspicious, the alert being worked is resolved with the score it was raised with
+	revised, err = store.ReviseAlert(ctx, &model.Alert{EventID: "e-200", Status: model.AlertResolved}, 2200)
+	require.NoError(t, err)
+	require.Equal(t, model.AlertResolved, revised.Status)
+	require.Equal(t, "alice", revised.Assignee)
+	require.Equal(t, 70, revised.Score)
+	require.Equal(t, "e-0", revised.PrecedingEventID)
+	require.Len(t, revised.History, 2)
+	change := revised.History[1]
+	require.Equal(t, model.AlertActorDetector, change.Actor)
+	require.Equal(t, model.AlertAcknowledged, change.PreviousStatus)
+	require.Equal(t, model.AlertResolved, change.Status)
+
+	// closed alerts and events without one are left alone
+	for _, eventID := range []string{"e-200", "e-300", "e-400"} {
+		revised, err = store.ReviseAlert(ctx, &model.Alert{EventID: eventID, Score: 10, Status: model.AlertOpen}, 2300)
+		require.NoError(t, err)
+		require.Nil(t, revised)
+	}
+	alert, err := store.Alert(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, model.AlertFalsePositive, alert.Status)
+	require.Equal(t, 70, alert.Score)
+}